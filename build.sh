@@ -72,6 +72,15 @@ for platform in "${PLATFORMS[@]}"; do
     cd ..
 done
 
+# Publish checksums so 'nmcrun upgrade' can verify downloads
+cd dist
+if command -v sha256sum >/dev/null 2>&1; then
+    sha256sum *.tar.gz *.zip > checksums.txt
+else
+    shasum -a 256 *.tar.gz *.zip > checksums.txt
+fi
+cd ..
+
 echo ""
 echo "🎉 Build completed! Archives created in dist/ directory:"
 ls -la dist/
@@ -0,0 +1,142 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"nmcrun/internal/console"
+)
+
+// appendToArchive rewrites target with its existing entries followed by the entries of the
+// new archives under a <timestamp>/ directory, so one growing bundle holds every collection
+// of an incident. target keeps its format and is created when it does not exist yet; it is
+// only replaced once the rewritten archive is complete, and its .sha256 is rewritten with it.
+// The new archives are removed after they were merged.
+func (c *Collector) appendToArchive(target string, archives []string) error {
+	format, err := archiveFormatOf(target)
+	if err != nil {
+		return err
+	}
+
+	info, statErr := os.Stat(target)
+	exists := statErr == nil
+	if !exists && !os.IsNotExist(statErr) {
+		return statErr
+	}
+	// CreateTemp makes the rewritten archive 0600; target keeps its mode, or gets the
+	// mode of the other archives
+	mode := fs.FileMode(0644)
+	if exists {
+		mode = info.Mode().Perm()
+	}
+
+	prefix := c.timestamp + "/"
+	console.Printf("\n📎 Appending %d archive(s) to %s under %s\n", len(archives), target, prefix)
+
+	// The rewritten archive is built next to target so the final rename stays on one filesystem
+	tempFile, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	archiveHash := sha256.New()
+	writer, err := c.newArchiveOfFormat(io.MultiWriter(tempFile, archiveHash), format, true)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	buffer := make([]byte, archiveBufferSize)
+	previous := 0
+	if exists {
+		if previous, err = copyArchiveEntries(writer, target, format, "", buffer); err != nil {
+			return fmt.Errorf("failed to read %s: %w", target, err)
+		}
+	}
+
+	dir := archiveEntryInfo{archiveEntry{name: prefix, mode: fs.ModeDir | 0755, modTime: time.Now()}}
+	if _, err := writer.create(prefix, dir, dir.ModTime()); err != nil {
+		return err
+	}
+	added := 0
+	for _, archiveName := range archives {
+		archiveFormat, err := archiveFormatOf(archiveName)
+		if err != nil {
+			return err
+		}
+		entries, err := copyArchiveEntries(writer, archiveName, archiveFormat, prefix, buffer)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", archiveName, err)
+		}
+		added += entries
+	}
+
+	// Flush explicitly so write errors (e.g. a full disk) are caught before target is replaced
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tempFile.Name(), mode); err != nil {
+		return err
+	}
+	if err := os.Rename(tempFile.Name(), target); err != nil {
+		return err
+	}
+	if err := writeChecksumFile(target, archiveHash.Sum(nil)); err != nil {
+		return err
+	}
+
+	for _, archiveName := range archives {
+		if err := os.Remove(archiveName); err != nil {
+			console.Printf("⚠️  Warning: Failed to remove %s after appending it: %v\n", archiveName, err)
+		}
+		os.Remove(archiveName + ".sha256")
+	}
+
+	if info, err := os.Stat(target); err == nil {
+		console.Printf("✅ %s now holds %d previous and %d new entries (%s)\n", target, previous, added, formatSize(info.Size()))
+	}
+	return nil
+}
+
+// copyArchiveEntries writes every entry of an archive to writer with prefix prepended to its
+// name, copying the content through buffer, and returns the number of entries copied
+func copyArchiveEntries(writer archive, archiveName, format, prefix string, buffer []byte) (int, error) {
+	reader, err := openArchive(archiveName, format)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	entries := 0
+	for {
+		entry, err := reader.next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+
+		content, err := writer.create(prefix+entry.name, archiveEntryInfo{entry}, entry.modTime)
+		if err != nil {
+			return entries, err
+		}
+		if !entry.mode.IsDir() {
+			// Hide ReadFrom/WriteTo so the copy goes through buffer instead of allocating its own
+			if _, err := io.CopyBuffer(struct{ io.Writer }{content}, struct{ io.Reader }{reader}, buffer); err != nil {
+				return entries, err
+			}
+		}
+		entries++
+	}
+}
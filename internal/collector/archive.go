@@ -0,0 +1,458 @@
+package collector
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Archive formats; the format is also the archive file extension
+const (
+	FormatTarGz  = "tar.gz"
+	FormatTarZst = "tar.zst"
+	FormatZip    = "zip"
+	FormatTar    = "tar"
+)
+
+// ArchiveFormats lists the supported archive formats, the default first
+var ArchiveFormats = []string{FormatTarGz, FormatTarZst, FormatZip, FormatTar}
+
+// archiveFormat returns the format of the archives created; plainTar selects plain tar
+// when no format is given
+func (o CollectorOptions) archiveFormat() string {
+	switch {
+	case o.ArchiveFormat != "":
+		return o.ArchiveFormat
+	case o.PlainTar:
+		return FormatTar
+	}
+	return FormatTarGz
+}
+
+// archiveBufferSize is the size of the buffer file contents are copied through. One buffer
+// is reused for every file of an archive, so memory stays flat however large the logs are.
+const archiveBufferSize = 256 * 1024
+
+// checksumsFileName is the entry listing the SHA-256 of every file of an archive, in
+// sha256sum format with names relative to the directory holding it
+const checksumsFileName = "SHA256SUMS"
+
+// archiveChecksums collects the SHA-256 of the files written to an archive
+type archiveChecksums struct {
+	lines []string
+}
+
+// add records the checksum of the file with the given name, relative to the SHA256SUMS entry
+func (s *archiveChecksums) add(name string, sum []byte) {
+	s.lines = append(s.lines, fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum), name))
+}
+
+// write adds the SHA256SUMS entry to the archive under name
+func (s *archiveChecksums) write(writer archive, name string) error {
+	content := []byte(strings.Join(s.lines, ""))
+	info := archiveEntryInfo{archiveEntry{name: name, size: int64(len(content)), mode: 0644, modTime: time.Now()}}
+	w, err := writer.create(name, info, info.ModTime())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// parseChecksums reads a SHA256SUMS entry found in directory dir of an archive and returns
+// the listed checksums by archive entry name
+func parseChecksums(r io.Reader, dir string) (map[string]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	sums := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if line == "" {
+			continue
+		}
+		sum, name, found := strings.Cut(line, "  ")
+		if !found {
+			return nil, fmt.Errorf("malformed %s line %q", checksumsFileName, line)
+		}
+		sums[path.Join(dir, name)] = sum
+	}
+	return sums, nil
+}
+
+// writeChecksumFile writes the SHA-256 of a whole archive next to it as <archive>.sha256,
+// in sha256sum format
+func writeChecksumFile(archiveName string, sum []byte) error {
+	line := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum), filepath.Base(archiveName))
+	return os.WriteFile(archiveName+".sha256", []byte(line), 0644)
+}
+
+// archive is an archive being written, in any of the archive formats
+type archive interface {
+	// create adds a directory (name ending in /) or a file, and returns the writer for its content
+	create(name string, fi os.FileInfo, modTime time.Time) (io.Writer, error)
+	Close() error
+}
+
+// newArchive creates the archive writer shared by all collection commands. Without compress,
+// content is stored (or compressed as fast as possible) because it is already compressed.
+func (c *Collector) newArchive(w io.Writer, compress bool) (archive, error) {
+	return c.newArchiveOfFormat(w, c.opts.archiveFormat(), compress)
+}
+
+// newArchiveOfFormat creates an archive writer in the given format, e.g. that of an
+// existing archive being rewritten
+func (c *Collector) newArchiveOfFormat(w io.Writer, format string, compress bool) (archive, error) {
+	if c.opts.MaxArchiveBytes > 0 {
+		w = &limitedWriter{w: w, limit: c.opts.MaxArchiveBytes}
+	}
+
+	switch format {
+	case FormatTar:
+		return &tarArchive{Writer: tar.NewWriter(w)}, nil
+	case FormatTarZst:
+		level := zstd.SpeedDefault
+		if !compress {
+			level = zstd.SpeedFastest
+		}
+		zstdWriter, err := zstd.NewWriter(w, zstd.WithEncoderLevel(level))
+		if err != nil {
+			return nil, err
+		}
+		return &tarArchive{Writer: tar.NewWriter(zstdWriter), compressor: zstdWriter}, nil
+	case FormatZip:
+		method := zip.Deflate
+		if !compress {
+			method = zip.Store
+		}
+		return &zipArchive{Writer: zip.NewWriter(w), method: method}, nil
+	}
+
+	level := gzip.DefaultCompression
+	if !compress {
+		level = gzip.NoCompression
+	}
+	gzipWriter, err := gzip.NewWriterLevel(w, level)
+	if err != nil {
+		return nil, err
+	}
+	return &tarArchive{Writer: tar.NewWriter(gzipWriter), compressor: gzipWriter}, nil
+}
+
+// tarArchive is a tar writer, wrapped in a gzip or zstd compressor unless it is a plain tar
+type tarArchive struct {
+	*tar.Writer
+	compressor io.WriteCloser
+}
+
+func (a *tarArchive) create(name string, fi os.FileInfo, modTime time.Time) (io.Writer, error) {
+	header, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return nil, err
+	}
+	header.Name = name
+	header.ModTime = modTime
+
+	if err := a.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	return a.Writer, nil
+}
+
+// Close flushes the tar stream and the compression layer, if any
+func (a *tarArchive) Close() error {
+	if err := a.Writer.Close(); err != nil {
+		return err
+	}
+	if a.compressor != nil {
+		return a.compressor.Close()
+	}
+	return nil
+}
+
+// zipArchive is a zip writer storing or deflating every file
+type zipArchive struct {
+	*zip.Writer
+	method uint16
+}
+
+func (a *zipArchive) create(name string, fi os.FileInfo, modTime time.Time) (io.Writer, error) {
+	header, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return nil, err
+	}
+	header.Name = name
+	header.Modified = modTime
+	if !fi.IsDir() {
+		header.Method = a.method
+	}
+	return a.CreateHeader(header)
+}
+
+// writeArchive archives dir and everything below it, with entry names relative to the
+// directory holding dir, and returns the number of entries written. Files are streamed one
+// at a time through a single buffer, and each directory is only listed, not stat'ed, up front.
+// The archive ends with <dir>/SHA256SUMS, and the SHA-256 of the archive itself is written
+// next to it as <archive>.sha256.
+func (c *Collector) writeArchive(dir, archiveName string, compress bool) (int, error) {
+	archiveFile, err := os.Create(archiveName)
+	if err != nil {
+		return 0, err
+	}
+	defer archiveFile.Close()
+
+	archiveHash := sha256.New()
+	writer, err := c.newArchive(io.MultiWriter(archiveFile, archiveHash), compress)
+	if err != nil {
+		return 0, err
+	}
+	defer writer.Close()
+
+	// Entry names are relative to the directory holding dir, so the archive
+	// extracts to a clean <dir>/... tree without host path prefixes
+	archiveRoot := filepath.Dir(filepath.Clean(dir))
+	dirName, err := archiveEntryName(archiveRoot, dir)
+	if err != nil {
+		return 0, err
+	}
+
+	buffer := make([]byte, archiveBufferSize)
+	sums := &archiveChecksums{}
+	entries := 0
+	err = filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := archiveEntryName(archiveRoot, file)
+		if err != nil {
+			return err
+		}
+		fi, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := c.addArchiveFile(writer, file, name, fi, buffer)
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			sums.add(strings.TrimPrefix(name, dirName+"/"), sum)
+		}
+		entries++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := sums.write(writer, dirName+"/"+checksumsFileName); err != nil {
+		return 0, err
+	}
+	entries++
+
+	// Flush explicitly so write errors (e.g. a full disk) are not lost in deferred closes
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+	if err := archiveFile.Close(); err != nil {
+		return 0, err
+	}
+	if err := writeChecksumFile(archiveName, archiveHash.Sum(nil)); err != nil {
+		return 0, err
+	}
+	return entries, nil
+}
+
+// addArchiveFile adds a file or directory to the archive under name, copying the file
+// content through buffer, and returns the SHA-256 of the file content
+func (c *Collector) addArchiveFile(writer archive, file, name string, fi os.FileInfo, buffer []byte) ([]byte, error) {
+	if fi.IsDir() {
+		_, err := writer.create(name+"/", fi, fi.ModTime())
+		return nil, err
+	}
+
+	// Date log files by their last log line rather than when they were written
+	modTime := fi.ModTime()
+	if !c.opts.NoTimestamps && strings.HasSuffix(file, ".log") {
+		if lastTimestamp, ok := lastLogTimestamp(file); ok {
+			modTime = lastTimestamp
+		}
+	}
+
+	data, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+
+	content, err := writer.create(name, fi, modTime)
+	if err != nil {
+		return nil, err
+	}
+	// The MultiWriter hides ReadFrom, so the copy goes through buffer instead of allocating its own
+	hash := sha256.New()
+	if _, err := io.CopyBuffer(io.MultiWriter(content, hash), struct{ io.Reader }{data}, buffer); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// limitedWriter counts the bytes written and fails once the limit would be exceeded
+type limitedWriter struct {
+	w       io.Writer
+	written int64
+	limit   int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.written+int64(len(p)) > l.limit {
+		return 0, fmt.Errorf("archive exceeds --max-archive-bytes (%d bytes); collect less data (e.g. with --since) or raise the limit", l.limit)
+	}
+	n, err := l.w.Write(p)
+	l.written += int64(n)
+	return n, err
+}
+
+// archiveEntry is an entry read back from an archive; directory names end in /
+type archiveEntry struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// archiveEntryInfo describes an archive entry as a file, so it can be written to another archive
+type archiveEntryInfo struct {
+	entry archiveEntry
+}
+
+func (i archiveEntryInfo) Name() string       { return path.Base(i.entry.name) }
+func (i archiveEntryInfo) Size() int64        { return i.entry.size }
+func (i archiveEntryInfo) Mode() fs.FileMode  { return i.entry.mode }
+func (i archiveEntryInfo) ModTime() time.Time { return i.entry.modTime }
+func (i archiveEntryInfo) IsDir() bool        { return i.entry.mode.IsDir() }
+func (i archiveEntryInfo) Sys() any           { return nil }
+
+// archiveReader iterates over the entries of an archive. After next, the entry content is
+// read from the archiveReader itself.
+type archiveReader interface {
+	io.Reader
+	// next advances to the next entry and returns io.EOF after the last one
+	next() (archiveEntry, error)
+	Close() error
+}
+
+// openArchive opens an archive written by newArchive in the given format
+func openArchive(archiveName, format string) (archiveReader, error) {
+	if format == FormatZip {
+		zipReader, err := zip.OpenReader(archiveName)
+		if err != nil {
+			return nil, err
+		}
+		return &zipArchiveReader{ReadCloser: zipReader}, nil
+	}
+
+	archiveFile, err := os.Open(archiveName)
+	if err != nil {
+		return nil, err
+	}
+	reader := &tarArchiveReader{file: archiveFile}
+	switch format {
+	case FormatTar:
+		reader.Reader = tar.NewReader(archiveFile)
+	case FormatTarZst:
+		zstdReader, err := zstd.NewReader(archiveFile)
+		if err != nil {
+			archiveFile.Close()
+			return nil, err
+		}
+		reader.Reader = tar.NewReader(zstdReader)
+		reader.release = zstdReader.Close
+	default:
+		gzipReader, err := gzip.NewReader(archiveFile)
+		if err != nil {
+			archiveFile.Close()
+			return nil, err
+		}
+		reader.Reader = tar.NewReader(gzipReader)
+	}
+	return reader, nil
+}
+
+// tarArchiveReader reads a plain or compressed tar archive
+type tarArchiveReader struct {
+	*tar.Reader
+	file    *os.File
+	release func()
+}
+
+func (r *tarArchiveReader) next() (archiveEntry, error) {
+	header, err := r.Next()
+	if err != nil {
+		return archiveEntry{}, err
+	}
+	return archiveEntry{name: header.Name, size: header.Size, mode: header.FileInfo().Mode(), modTime: header.ModTime}, nil
+}
+
+func (r *tarArchiveReader) Close() error {
+	if r.release != nil {
+		r.release()
+	}
+	return r.file.Close()
+}
+
+// zipArchiveReader reads the entries of a zip archive in the order they were written
+type zipArchiveReader struct {
+	*zip.ReadCloser
+	index   int
+	current io.ReadCloser
+}
+
+func (r *zipArchiveReader) next() (archiveEntry, error) {
+	if r.current != nil {
+		r.current.Close()
+		r.current = nil
+	}
+	if r.index >= len(r.File) {
+		return archiveEntry{}, io.EOF
+	}
+	file := r.File[r.index]
+	r.index++
+
+	content, err := file.Open()
+	if err != nil {
+		return archiveEntry{}, fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	r.current = content
+	return archiveEntry{name: file.Name, size: int64(file.UncompressedSize64), mode: file.Mode(), modTime: file.Modified}, nil
+}
+
+func (r *zipArchiveReader) Read(p []byte) (int, error) {
+	if r.current == nil {
+		return 0, io.EOF
+	}
+	return r.current.Read(p)
+}
+
+func (r *zipArchiveReader) Close() error {
+	if r.current != nil {
+		r.current.Close()
+	}
+	return r.ReadCloser.Close()
+}
+
+// archiveExtension is the file extension of the archives created
+func (c *Collector) archiveExtension() string {
+	return "." + c.opts.archiveFormat()
+}
@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// Size of the memory test collection: large logs, more data than the archive code may allocate
+const (
+	memoryTestFiles    = 8
+	memoryTestFileSize = 8 << 20
+)
+
+func TestWriteArchiveStreamsFiles(t *testing.T) {
+	dir := t.TempDir()
+	logDir := filepath.Join(dir, "large", "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	line := "2024-06-01T10:00:00.000000000Z large log line for the archive memory check\n"
+	content := []byte(strings.Repeat(line, memoryTestFileSize/len(line)))
+	for i := 0; i < memoryTestFiles; i++ {
+		writeFile(t, filepath.Join(logDir, fmt.Sprintf("pod-%d_main.log", i)), string(content))
+	}
+	content = nil
+
+	c := &Collector{opts: CollectorOptions{PlainTar: true}}
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	if _, err := c.writeArchive(filepath.Dir(logDir), filepath.Join(dir, "large.tar"), true); err != nil {
+		t.Fatalf("writeArchive: %v", err)
+	}
+	runtime.ReadMemStats(&after)
+
+	// Archiving must stream files instead of reading them into memory
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated >= memoryTestFileSize {
+		t.Errorf("archiving %d MiB allocated %d KiB, files are not streamed", memoryTestFiles*memoryTestFileSize>>20, allocated>>10)
+	}
+}
+
+func TestAppendToArchiveKeepsTargetMode(t *testing.T) {
+	dir := t.TempDir()
+	c := &Collector{timestamp: "2024-06-01_10-00-00"}
+
+	archive := func(name string) string {
+		file := filepath.Join(dir, name+".log")
+		writeFile(t, file, name+"\n")
+		archiveName := filepath.Join(dir, name+c.archiveExtension())
+		if err := c.createWorkloadArchive(archiveName, []string{file}); err != nil {
+			t.Fatalf("createWorkloadArchive: %v", err)
+		}
+		return archiveName
+	}
+
+	target := archive("bundle")
+	if err := os.Chmod(target, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.appendToArchive(target, []string{archive("first")}); err != nil {
+		t.Fatalf("appendToArchive: %v", err)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0640 {
+		t.Errorf("existing target mode = %o, want %o", mode, 0640)
+	}
+
+	created := filepath.Join(dir, "new"+c.archiveExtension())
+	if err := c.appendToArchive(created, []string{archive("second")}); err != nil {
+		t.Fatalf("appendToArchive: %v", err)
+	}
+	if info, err = os.Stat(created); err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0644 {
+		t.Errorf("new target mode = %o, want %o", mode, 0644)
+	}
+}
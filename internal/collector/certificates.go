@@ -0,0 +1,212 @@
+package collector
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// tlsDialTimeout bounds the TLS handshake with each ingress host
+const tlsDialTimeout = 10 * time.Second
+
+// tlsEndpoint is a host exposed by an Ingress or Route, with the certificate it is
+// configured with, if any
+type tlsEndpoint struct {
+	host   string
+	source string
+	// secretName is the Ingress TLS secret for the host
+	secretName string
+	// certificate is the PEM certificate set inline on a Route
+	certificate string
+}
+
+// getServedCertificates writes, for every Ingress and Route host in the namespace, the
+// certificate chain served on port 443 and the certificate it is configured with
+func (c *Collector) getServedCertificates(namespace string) (string, error) {
+	endpoints, err := c.tlsEndpoints(namespace)
+	if err != nil {
+		return "", err
+	}
+	if len(endpoints) == 0 {
+		return fmt.Sprintf("No Ingress or Route hosts in namespace %s\n", namespace), nil
+	}
+
+	now := time.Now()
+	var output strings.Builder
+	for _, endpoint := range endpoints {
+		output.WriteString(fmt.Sprintf("=== %s (%s) ===\n", endpoint.host, endpoint.source))
+
+		chain, err := fetchServedChain(endpoint.host)
+		if err != nil {
+			output.WriteString(fmt.Sprintf("Served certificate: unavailable: %v\n", err))
+		} else {
+			output.WriteString(fmt.Sprintf("Served chain (%d certificate(s)):\n", len(chain)))
+			for i, cert := range chain {
+				describeCertificate(&output, i, cert, now)
+			}
+			output.WriteString(fmt.Sprintf("Verification:     %s\n", verifyServedChain(endpoint.host, chain)))
+		}
+
+		switch {
+		case endpoint.secretName != "":
+			certs, err := c.secretCertificates(namespace, endpoint.secretName)
+			if err != nil {
+				output.WriteString(fmt.Sprintf("TLS secret %s: %v\n", endpoint.secretName, err))
+				break
+			}
+			output.WriteString(fmt.Sprintf("TLS secret %s (%d certificate(s)):\n", endpoint.secretName, len(certs)))
+			for i, cert := range certs {
+				describeCertificate(&output, i, cert, now)
+			}
+		case endpoint.certificate != "":
+			certs, err := parseCertificates([]byte(endpoint.certificate))
+			if err != nil {
+				output.WriteString(fmt.Sprintf("Route certificate: %v\n", err))
+				break
+			}
+			output.WriteString(fmt.Sprintf("Route certificate (%d certificate(s)):\n", len(certs)))
+			for i, cert := range certs {
+				describeCertificate(&output, i, cert, now)
+			}
+		}
+		output.WriteString("\n")
+	}
+	return output.String(), nil
+}
+
+// tlsEndpoints lists the hosts of the namespace Ingresses and, on OpenShift, Routes
+func (c *Collector) tlsEndpoints(namespace string) ([]tlsEndpoint, error) {
+	ingresses, err := c.clientset.NetworkingV1().Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	var endpoints []tlsEndpoint
+	seen := map[string]bool{}
+	add := func(endpoint tlsEndpoint) {
+		if endpoint.host == "" || seen[endpoint.host] {
+			return
+		}
+		seen[endpoint.host] = true
+		endpoints = append(endpoints, endpoint)
+	}
+
+	for _, ingress := range ingresses.Items {
+		source := fmt.Sprintf("ingress %s", ingress.Name)
+		for _, tlsConfig := range ingress.Spec.TLS {
+			for _, host := range tlsConfig.Hosts {
+				add(tlsEndpoint{host: host, source: source, secretName: tlsConfig.SecretName})
+			}
+		}
+		for _, rule := range ingress.Spec.Rules {
+			add(tlsEndpoint{host: rule.Host, source: source})
+		}
+	}
+
+	if c.servesResource(routeGVR) {
+		routes, err := c.dynamicClient.Resource(routeGVR).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list routes: %w", err)
+		}
+		for _, route := range routes.Items {
+			host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+			certificate, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "certificate")
+			add(tlsEndpoint{host: host, source: fmt.Sprintf("route %s", route.GetName()), certificate: certificate})
+		}
+	}
+	return endpoints, nil
+}
+
+// fetchServedChain returns the certificate chain the host serves on port 443. The chain
+// is not verified here so that expired or mismatched certificates can still be reported.
+func fetchServedChain(host string) ([]*x509.Certificate, error) {
+	dialer := &net.Dialer{Timeout: tlsDialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, "443"), &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates, nil
+}
+
+// verifyServedChain verifies the served chain against the system roots and the host name
+func verifyServedChain(host string, chain []*x509.Certificate) string {
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := chain[0].Verify(x509.VerifyOptions{DNSName: host, Intermediates: intermediates}); err != nil {
+		return fmt.Sprintf("FAILED: %v", err)
+	}
+	return "OK"
+}
+
+// secretCertificates decodes the tls.crt of a kubernetes.io/tls secret
+func (c *Collector) secretCertificates(namespace, name string) ([]*x509.Certificate, error) {
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data, exists := secret.Data["tls.crt"]
+	if !exists {
+		return nil, fmt.Errorf("secret has no tls.crt")
+	}
+	return parseCertificates(data)
+}
+
+// parseCertificates parses the CERTIFICATE blocks of PEM data
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return certs, nil
+}
+
+// describeCertificate writes a certificate's subject, SANs, issuer and validity
+func describeCertificate(output *strings.Builder, index int, cert *x509.Certificate, now time.Time) {
+	var sans []string
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	expiry := fmt.Sprintf("expires in %d day(s)", int(cert.NotAfter.Sub(now).Hours()/24))
+	if now.After(cert.NotAfter) {
+		expiry = fmt.Sprintf("EXPIRED %d day(s) ago", int(now.Sub(cert.NotAfter).Hours()/24))
+	} else if now.Before(cert.NotBefore) {
+		expiry = "NOT YET VALID"
+	}
+
+	output.WriteString(fmt.Sprintf("  [%d] Subject:    %s\n", index, cert.Subject))
+	output.WriteString(fmt.Sprintf("      SANs:       %s\n", valueOrNone(strings.Join(sans, ", "))))
+	output.WriteString(fmt.Sprintf("      Issuer:     %s\n", cert.Issuer))
+	output.WriteString(fmt.Sprintf("      NotBefore:  %s\n", cert.NotBefore.UTC().Format(time.RFC3339)))
+	output.WriteString(fmt.Sprintf("      NotAfter:   %s (%s)\n", cert.NotAfter.UTC().Format(time.RFC3339), expiry))
+}
@@ -1,18 +1,29 @@
 package collector
 
 import (
-	"archive/tar"
+	"bufio"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"nmcrun/internal/console"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -25,20 +36,93 @@ import (
 )
 
 type Collector struct {
-	namespaces    []string
-	logDir        string
-	timestamp     string
-	clientset     *kubernetes.Clientset
-	dynamicClient dynamic.Interface
-	config        *rest.Config
+	opts           CollectorOptions
+	redactPatterns []*regexp.Regexp
+	grepPattern    *regexp.Regexp
+	extraResources []extraResource
+	logDir         string
+	timestamp      string
+	startTime      time.Time
+	authMethod     string
+	clientset      kubernetes.Interface
+	dynamicClient  dynamic.Interface
+	config         *rest.Config
+	archiveWriter  io.Writer
+
+	// connectDuration is how long it took to set up the cluster connection
+	connectDuration time.Duration
+
+	// Namespace RunAI is installed in, discovered on first use
+	runaiNamespaceOnce sync.Once
+	runaiNamespace     string
+
+	// RunAI cluster version, detected on first use to select the collected APIs
+	runaiVersionOnce  sync.Once
+	runaiVersion      runaiVersion
+	runaiVersionKnown bool
+
+	// Whether resources are namespaced, cached from discovery
+	scopeMu        sync.Mutex
+	namespacedGVRs map[schema.GroupVersionResource]bool
+
+	// commands records the kubectl equivalent of each API read for commands.txt
+	commands *commandRecorder
+
+	// failed lists the items of the current namespace that could not be collected
+	failed failureList
+
+	// progress receives the NDJSON progress events, if set
+	progress *progressStream
+}
+
+// SetArchiveWriter sets where the archive is streamed when the output option is "-"
+func (c *Collector) SetArchiveWriter(w io.Writer) {
+	c.archiveWriter = w
 }
 
 // New creates a new collector instance
-func New() (*Collector, error) {
-	restConfig, err := getKubernetesConfig()
+func New(opts CollectorOptions) (*Collector, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+
+	redactPatterns, err := compileRedactRules(opts.Redact)
+	if err != nil {
+		return nil, err
+	}
+
+	grepPattern, err := compileGrep(opts.Grep)
+	if err != nil {
+		return nil, err
+	}
+
+	extraResources, err := parseExtraResources(opts.ExtraResources)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	if opts.UTC {
+		startTime = startTime.UTC()
+	}
+
+	connectStart := time.Now()
+	restConfig, authMethod, err := getKubernetesConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kubernetes config: %w", err)
 	}
+	if opts.APIQPS > 0 {
+		restConfig.QPS = opts.APIQPS
+	}
+	if opts.APIBurst > 0 {
+		restConfig.Burst = opts.APIBurst
+	}
+	if opts.MaxInflight > 0 {
+		// The typed and dynamic clients share one set of slots, bounding all requests together
+		restConfig.WrapTransport = inflightWrapper(opts.MaxInflight)
+	}
+	commands := &commandRecorder{}
+	restConfig.Wrap(commands.wrap)
 
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(restConfig)
@@ -53,41 +137,63 @@ func New() (*Collector, error) {
 	}
 
 	return &Collector{
-		namespaces:    []string{"runai-backend", "runai"},
-		timestamp:     time.Now().Format("02-01-2006_15-04"),
-		clientset:     clientset,
-		dynamicClient: dynamicClient,
-		config:        restConfig,
+		opts:           opts,
+		redactPatterns: redactPatterns,
+		grepPattern:    grepPattern,
+		extraResources: extraResources,
+		startTime:      startTime,
+		timestamp:      archiveTimestamp(opts, startTime, legacyTimestampLayout),
+		clientset:      clientset,
+		dynamicClient:  dynamicClient,
+		config:         restConfig,
+		authMethod:     authMethod,
+		archiveWriter:  os.Stdout,
+
+		connectDuration: time.Since(connectStart),
+		commands:        commands,
 	}, nil
 }
 
-// getKubernetesConfig creates a Kubernetes REST config using multiple authentication methods
-func getKubernetesConfig() (*rest.Config, error) {
-	// Method 1: Try in-cluster config first (for pods running inside the cluster)
-	if config, err := rest.InClusterConfig(); err == nil {
-		fmt.Printf("🔗 Using in-cluster authentication\n")
-		return config, nil
+// archiveTimestamp formats the collection start time for archive names, using
+// legacyLayout when the old timestamp format is requested
+func archiveTimestamp(opts CollectorOptions, startTime time.Time, legacyLayout string) string {
+	if opts.LegacyTimestamps {
+		return startTime.Format(legacyLayout)
 	}
+	return startTime.Format(archiveTimestampLayout)
+}
 
-	// Method 2: Try kubeconfig file
-	if config, err := tryKubeconfigAuth(); err == nil {
-		fmt.Printf("🔗 Using kubeconfig file authentication\n")
-		return config, nil
-	}
+// Authentication methods reported by getKubernetesConfig
+const (
+	authInCluster      = "in-cluster"
+	authKubeconfig     = "kubeconfig"
+	authServiceAccount = "service-account"
+	authEnvironment    = "environment"
+)
 
-	// Method 3: Try service account token file
-	if config, err := tryServiceAccountTokenAuth(); err == nil {
-		fmt.Printf("🔗 Using service account token authentication\n")
-		return config, nil
-	}
+// authMethods are the authentication methods getKubernetesConfig tries, in order
+var authMethods = []struct {
+	name        string
+	description string
+	try         func() (*rest.Config, error)
+}{
+	{authInCluster, "in-cluster", rest.InClusterConfig},
+	{authKubeconfig, "kubeconfig file", tryKubeconfigAuth},
+	{authServiceAccount, "service account token", tryServiceAccountTokenAuth},
+	{authEnvironment, "environment variable", tryEnvironmentAuth},
+}
 
-	// Method 4: Try environment variables
-	if config, err := tryEnvironmentAuth(); err == nil {
-		fmt.Printf("🔗 Using environment variable authentication\n")
-		return config, nil
+// getKubernetesConfig creates a Kubernetes REST config using multiple authentication methods
+// and returns the method that succeeded
+func getKubernetesConfig() (*rest.Config, string, error) {
+	for _, method := range authMethods {
+		if config, err := method.try(); err == nil {
+			console.Printf("🔗 Using %s authentication\n", method.description)
+			return config, method.name, nil
+		}
 	}
 
-	return nil, fmt.Errorf(`no valid Kubernetes authentication method found. Please ensure one of the following:
+	return nil, "", fmt.Errorf(`no valid Kubernetes authentication method found. Please ensure one of the following:
 1. Running inside a Kubernetes cluster with a service account
 2. Have a valid kubeconfig file at ~/.kube/config or set KUBECONFIG env var
 3. Have KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT environment variables set
@@ -96,22 +202,30 @@ func getKubernetesConfig() (*rest.Config, error) {
 For more details, see: https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/`)
 }
 
-// tryKubeconfigAuth attempts to authenticate using kubeconfig files
-func tryKubeconfigAuth() (*rest.Config, error) {
-	// Use the default loading rules (checks KUBECONFIG env var, ~/.kube/config, etc.)
+// newKubeconfigLoader builds the kubeconfig client config shared by authentication and
+// context reporting, so the reported context always matches the one used for collection.
+// The default loading rules merge every file listed in KUBECONFIG, falling back to ~/.kube/config.
+func newKubeconfigLoader() clientcmd.ClientConfig {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+}
 
-	// Load the config
-	config, err := loadingRules.Load()
+// currentContextName returns the context a kubeconfig client config resolves to
+func currentContextName(loader clientcmd.ClientConfig) (string, error) {
+	rawConfig, err := loader.RawConfig()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	if rawConfig.CurrentContext == "" {
+		return "", fmt.Errorf("no current context set in kubeconfig")
+	}
+	return rawConfig.CurrentContext, nil
+}
 
-	// Create client config
-	clientConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
-
-	// Get REST config
-	return clientConfig.ClientConfig()
+// tryKubeconfigAuth attempts to authenticate using kubeconfig files
+func tryKubeconfigAuth() (*rest.Config, error) {
+	return newKubeconfigLoader().ClientConfig()
 }
 
 // tryServiceAccountTokenAuth attempts to authenticate using a service account token file
@@ -139,10 +253,12 @@ func tryServiceAccountTokenAuth() (*rest.Config, error) {
 		return nil, fmt.Errorf("KUBERNETES_SERVICE_HOST or KUBERNETES_SERVICE_PORT environment variables not set")
 	}
 
-	// Create config
+	// Kubelet rotates projected tokens; with BearerTokenFile client-go re-reads the file,
+	// so long collections keep working after the token read here expires
 	config := &rest.Config{
-		Host:        fmt.Sprintf("https://%s:%s", host, port),
-		BearerToken: string(token),
+		Host:            fmt.Sprintf("https://%s:%s", host, port),
+		BearerToken:     string(token),
+		BearerTokenFile: tokenFile,
 	}
 
 	// Set CA certificate if available
@@ -170,8 +286,13 @@ func tryEnvironmentAuth() (*rest.Config, error) {
 		Host: fmt.Sprintf("https://%s:%s", host, port),
 	}
 
-	// Use token if provided
-	if token != "" {
+	// Use token if provided; a token file is re-read by client-go when the token is rotated
+	if tokenFile := os.Getenv("KUBERNETES_TOKEN_FILE"); tokenFile != "" {
+		if _, err := os.ReadFile(tokenFile); err != nil {
+			return nil, fmt.Errorf("failed to read KUBERNETES_TOKEN_FILE: %w", err)
+		}
+		config.BearerTokenFile = tokenFile
+	} else if token != "" {
 		config.BearerToken = token
 	}
 
@@ -188,73 +309,243 @@ func tryEnvironmentAuth() (*rest.Config, error) {
 
 // Run executes the log collection process
 func (c *Collector) Run() error {
-	fmt.Println("🚀 Starting RunAI log collection...")
+	console.Println("🚀 Starting RunAI log collection...")
+	runStart := time.Now()
 
 	// Check required tools
 	if err := c.checkRequiredTools(); err != nil {
 		return fmt.Errorf("required tools check failed: %w", err)
 	}
 
+	// An unreachable cluster is reported on its own so CI can tell it apart
+	if err := c.testClusterConnection(); err != nil {
+		return exitError(ExitConnectivity, fmt.Errorf("cannot connect to Kubernetes cluster: %w", err))
+	}
+
+	// Collection continues without the runaiconfig, but the exit code reports it
+	_, configErr := c.getRunAIConfig()
+	if configErr != nil {
+		console.Printf("⚠️  Warning: Cannot read the runaiconfig resource: %v\n", configErr)
+	}
+
 	// Extract cluster information
 	clusterURL, cpURL, err := c.extractClusterInfo()
 	if err != nil {
-		fmt.Printf("⚠ Warning: Could not extract cluster information: %v\n", err)
+		console.Printf("⚠ Warning: Could not extract cluster information: %v\n", err)
 		clusterURL = "unknown"
 		cpURL = "unknown"
 	}
 
 	cpNameClean := c.cleanControlPlaneName(cpURL)
 
-	fmt.Printf("Cluster URL: %s\n", clusterURL)
-	fmt.Printf("Control Plane URL: %s\n", cpURL)
-	fmt.Printf("Control Plane Name (cleaned): %s\n", cpNameClean)
-	fmt.Println("==========================================")
+	console.Printf("Cluster URL: %s\n", clusterURL)
+	console.Printf("Control Plane URL: %s\n", cpURL)
+	console.Printf("Control Plane Name (cleaned): %s\n", cpNameClean)
+	console.Println("==========================================")
+
+	namespaces, err := c.namespacesToCollect()
+	if err != nil {
+		return err
+	}
 
 	// Process each namespace
-	for _, namespace := range c.namespaces {
-		fmt.Printf("\n🔍 Processing namespace: %s\n", namespace)
-		fmt.Println("----------------------------------------")
+	var missing, incomplete, archives []string
+	var summaries []*namespaceSummary
+	defer func() { printRunSummary(summaries) }()
+	for _, namespace := range namespaces {
+		console.Printf("\n🔍 Processing namespace: %s\n", namespace)
+		console.Println("----------------------------------------")
+
+		summary := &namespaceSummary{namespace: namespace}
+		summaries = append(summaries, summary)
+		c.emit(progressEvent{Phase: "namespace", Namespace: namespace, Status: progressStart}, nil)
 
 		// Check if namespace exists
-		if !c.namespaceExists(namespace) {
-			fmt.Printf("❌ Namespace '%s' does not exist. Skipping.\n", namespace)
+		exists, err := c.namespaceExists(namespace)
+		if err != nil {
+			console.Printf("❌ Cannot access namespace '%s': %v. Skipping.\n", namespace, err)
+			summary.errors++
+			c.emit(progressEvent{Phase: "namespace", Namespace: namespace}, err)
+			incomplete = append(incomplete, namespace)
+			continue
+		}
+		if !exists {
+			console.Printf("❌ Namespace '%s' does not exist. Skipping.\n", namespace)
+			summary.errors++
+			c.emit(progressEvent{Phase: "namespace", Namespace: namespace}, fmt.Errorf("namespace does not exist"))
+			missing = append(missing, namespace)
 			continue
 		}
 
-		fmt.Printf("✓ Namespace '%s' exists. Starting log collection...\n", namespace)
+		console.Printf("✓ Namespace '%s' exists. Starting log collection...\n", namespace)
 
-		logName := fmt.Sprintf("%s-%s-logs-%s", cpNameClean, namespace, c.timestamp)
+		logName := fmt.Sprintf("%s%s-%s-logs-%s", c.opts.OutputPrefix, cpNameClean, namespace, c.timestamp)
 		logDir := fmt.Sprintf("./%s", logName)
-		archiveName := fmt.Sprintf("%s.tar.gz", logName)
+		if c.opts.Resume != "" {
+			logDir = filepath.Clean(c.opts.Resume)
+			logName = filepath.Base(logDir)
+		}
+		archiveName := logName + c.archiveExtension()
+		if c.opts.Output != "" && c.opts.Output != "-" {
+			archiveName = c.opts.Output
+		}
+
+		err = c.processNamespace(namespace, logDir, archiveName, clusterURL, cpURL, summary)
+		if info, statErr := os.Stat(archiveName); statErr == nil {
+			summary.archiveSize = info.Size()
+		}
+		c.emit(progressEvent{Phase: "namespace", Namespace: namespace, Item: archiveName}, err)
+		if err != nil {
+			console.Printf("❌ Error processing namespace %s: %v\n", namespace, err)
+			summary.errors++
+			incomplete = append(incomplete, namespace)
+			if c.opts.Output == "-" {
+				return fmt.Errorf("failed to collect namespace %s: %w", namespace, err)
+			}
+			continue
+		}
+
+		if len(summary.failures) > 0 {
+			incomplete = append(incomplete, namespace)
+		}
 
-		if err := c.processNamespace(namespace, logDir, archiveName, clusterURL, cpURL); err != nil {
-			fmt.Printf("❌ Error processing namespace %s: %v\n", namespace, err)
+		if c.opts.Output == "-" {
+			if err := c.streamArchive(archiveName); err != nil {
+				return fmt.Errorf("failed to stream archive: %w", err)
+			}
+			console.Printf("✓ Completed processing namespace: %s\n", namespace)
+			console.Println("Archive written to stdout")
+			console.Println("==========================================")
 			continue
 		}
 
-		fmt.Printf("✓ Completed processing namespace: %s\n", namespace)
-		fmt.Printf("Archive created: %s\n", archiveName)
-		fmt.Println("==========================================")
+		console.Printf("✓ Completed processing namespace: %s\n", namespace)
+		console.Printf("Archive created: %s\n", archiveName)
+		console.Println("==========================================")
+		archives = append(archives, archiveName)
+	}
+
+	if c.opts.AppendTo != "" && len(archives) > 0 {
+		if err := c.appendToArchive(c.opts.AppendTo, archives); err != nil {
+			return fmt.Errorf("failed to append to %s, keeping %s: %w", c.opts.AppendTo, strings.Join(archives, ", "), err)
+		}
+	}
+
+	var problems []string
+	var codes []int
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("namespaces not found: %s", strings.Join(missing, ", ")))
+		codes = append(codes, ExitNamespacesMissing)
+	}
+	if len(incomplete) > 0 {
+		problems = append(problems, fmt.Sprintf("incomplete collection (see the failed items in script.log): %s", strings.Join(incomplete, ", ")))
+		codes = append(codes, ExitPartial)
+	}
+	if configErr != nil {
+		problems = append(problems, fmt.Sprintf("runaiconfig unreadable: %v", configErr))
+		codes = append(codes, ExitConfigUnreadable)
 	}
 
-	fmt.Println("\n🎉 All namespaces processed successfully!")
+	console.Printf("⏱️  Total collection time: %s (cluster connection: %s)\n",
+		time.Since(runStart).Round(time.Millisecond), c.connectDuration.Round(time.Millisecond))
+	if len(problems) > 0 {
+		console.Println("\n⚠️  All namespaces processed, with problems")
+		return exitError(lowestExitCode(codes...), fmt.Errorf("collection finished with problems: %s", strings.Join(problems, "; ")))
+	}
+	console.Println("\n🎉 All namespaces processed successfully!")
 	return nil
 }
 
+// namespacesToCollect returns the configured namespaces, or those matching NamespaceSelector,
+// plus every RunAI-labelled namespace when AllRunAINamespaces is set
+func (c *Collector) namespacesToCollect() ([]string, error) {
+	if c.opts.Resume != "" {
+		namespace, err := resumeNamespace(c.opts.Resume)
+		if err != nil {
+			return nil, err
+		}
+		console.Printf("🔁 Resuming collection of namespace %s in %s\n", namespace, c.opts.Resume)
+		return []string{namespace}, nil
+	}
+
+	namespaces := append([]string{}, c.opts.Namespaces...)
+
+	if c.opts.NamespaceSelector != "" {
+		selected, err := c.getNamespacesByLabel(c.opts.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces matching --namespace-selector %q: %w", c.opts.NamespaceSelector, err)
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no namespace matches --namespace-selector %q", c.opts.NamespaceSelector)
+		}
+		namespaces = selected
+		console.Printf("🔎 Selected %d namespace(s) by label %s: %s\n", len(selected), c.opts.NamespaceSelector, strings.Join(selected, ", "))
+	}
+
+	if c.opts.AllRunAINamespaces {
+		discovered, err := c.discoverRunAINamespaces()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover RunAI namespaces: %w", err)
+		}
+		for _, namespace := range discovered {
+			if !containsString(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		console.Printf("🔎 Discovered %d RunAI namespace(s): %s\n", len(discovered), strings.Join(discovered, ", "))
+	}
+
+	if len(c.opts.ExcludeNamespaces) > 0 {
+		var kept []string
+		for _, namespace := range namespaces {
+			if pattern, excluded := matchingPattern(namespace, c.opts.ExcludeNamespaces); excluded {
+				console.Printf("⏭️  Excluding namespace %s (matches --exclude-namespaces %q)\n", namespace, pattern)
+				continue
+			}
+			kept = append(kept, namespace)
+		}
+		namespaces = kept
+	}
+
+	if c.opts.Output != "" && len(namespaces) != 1 {
+		return nil, fmt.Errorf("--output requires exactly one namespace, got %d", len(namespaces))
+	}
+
+	return namespaces, nil
+}
+
+// discoverRunAINamespaces lists namespaces carrying a RunAI label
+func (c *Collector) discoverRunAINamespaces() ([]string, error) {
+	var namespaces []string
+	for _, selector := range []string{"runai/queue", "app.kubernetes.io/managed-by=runai"} {
+		selected, err := c.getNamespacesByLabel(selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range selected {
+			if !containsString(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
 // checkRequiredTools verifies that required tools are available
 func (c *Collector) checkRequiredTools() error {
-	fmt.Println("🔧 Checking system requirements...")
+	console.Println("🔧 Checking system requirements...")
 
 	// No external tools required! Everything is handled by native Go libraries
-	fmt.Println("✅ All requirements satisfied (no external tools needed)")
+	console.Println("✅ All requirements satisfied (no external tools needed)")
 	return nil
 }
 
 // extractClusterInfo gets cluster and control plane URLs
 func (c *Collector) extractClusterInfo() (string, string, error) {
 	// Get the runaiconfig resource using dynamic client
-	gvr := schema.GroupVersionResource{Group: "run.ai", Version: "v1", Resource: "runaiconfigs"}
-	obj, err := c.dynamicClient.Resource(gvr).Namespace("runai").Get(context.TODO(), "runai", metav1.GetOptions{})
+	obj, err := c.getRunAIConfig()
 	if err != nil {
 		return "unknown", "unknown", nil
 	}
@@ -274,59 +565,209 @@ func (c *Collector) extractClusterInfo() (string, string, error) {
 	return strings.TrimSpace(clusterURL), strings.TrimSpace(cpURL), nil
 }
 
+// getRunAIConfig finds the runaiconfig resource. It prefers the conventional
+// runai/runai object and otherwise takes the first runaiconfig it can list,
+// first in the RunAI namespace and then cluster-wide.
+func (c *Collector) getRunAIConfig() (*unstructured.Unstructured, error) {
+	gvr := schema.GroupVersionResource{Group: "run.ai", Version: "v1", Resource: "runaiconfigs"}
+	namespace := c.findRunAINamespace()
+
+	obj, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(context.TODO(), "runai", metav1.GetOptions{})
+	if err == nil {
+		return obj, nil
+	}
+
+	for _, ns := range []string{namespace, metav1.NamespaceAll} {
+		list, listErr := c.dynamicClient.Resource(gvr).Namespace(ns).List(context.TODO(), metav1.ListOptions{})
+		if listErr != nil {
+			err = listErr
+			continue
+		}
+		if len(list.Items) > 0 {
+			return &list.Items[0], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no runaiconfig found: %w", err)
+}
+
+// findRunAINamespace returns the namespace RunAI is installed in, falling back
+// to a namespace labelled as part of RunAI when "runai" does not exist. It is
+// discovered once per collector.
+func (c *Collector) findRunAINamespace() string {
+	c.runaiNamespaceOnce.Do(func() {
+		c.runaiNamespace = "runai"
+		// Keep the default when the namespace cannot be checked (e.g. no RBAC on namespaces)
+		if exists, err := c.namespaceExists("runai"); exists || err != nil {
+			return
+		}
+		if namespace, err := c.getNamespaceByLabel("app.kubernetes.io/part-of=runai"); err == nil && strings.TrimSpace(namespace) != "" {
+			c.runaiNamespace = strings.TrimSpace(namespace)
+		}
+	})
+	return c.runaiNamespace
+}
+
+// getRunAIConfigYAML gets the discovered runaiconfig as YAML
+func (c *Collector) getRunAIConfigYAML() (string, error) {
+	obj, err := c.getRunAIConfig()
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(obj)
+}
+
 // cleanControlPlaneName cleans the control plane URL for use in filenames
 func (c *Collector) cleanControlPlaneName(cpURL string) string {
-	// Remove https:// and replace dots with dashes
-	clean := strings.Replace(cpURL, "https://", "", 1)
-	clean = strings.Replace(clean, ".", "-", -1)
+	host := strings.TrimSpace(cpURL)
+
+	// Strip any scheme, then drop path/query/fragment, credentials and port
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+len("://"):]
+	}
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	// Replace everything outside [A-Za-z0-9-] (dots included) with dashes
+	clean := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, host)
+	clean = strings.Trim(clean, "-")
+
+	if clean == "" {
+		return "unknown"
+	}
 	return clean
 }
 
 // removed - replaced with client-go version
 
 // processNamespace handles log collection for a single namespace
-func (c *Collector) processNamespace(namespace, logDir, archiveName, clusterURL, cpURL string) error {
+func (c *Collector) processNamespace(namespace, logDir, archiveName, clusterURL, cpURL string, summary *namespaceSummary) error {
 	// Create log directory
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		return fmt.Errorf("failed to create log directory: %w", err)
 	}
 
+	// A resumed collection keeps the script log of the interrupted run
 	scriptLogPath := filepath.Join(logDir, "script.log")
-	scriptLog, err := os.Create(scriptLogPath)
+	scriptLogFlags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if c.opts.Resume != "" {
+		scriptLogFlags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	scriptLogFile, err := os.OpenFile(scriptLogPath, scriptLogFlags, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to create script log: %w", err)
 	}
-	defer scriptLog.Close()
+	defer scriptLogFile.Close()
+	scriptLog := io.Writer(scriptLogFile)
 
 	// Write header to script log
 	c.writeScriptLogHeader(scriptLog, namespace, clusterURL, cpURL)
 
+	// commands.txt and the retry round only cover this namespace
+	c.commands.take()
+	c.takeFailures()
+
+	timings := &phaseTimings{}
+	timings.entries = append(timings.entries, timing{phase: "connect", duration: c.connectDuration})
+
+	var podLogsDuration, additionalInfoDuration time.Duration
+
 	// Collect pod logs
-	fmt.Println("📋 === Collecting Pod Logs ===")
-	fmt.Fprintln(scriptLog, "=== Collecting Pod Logs ===")
-	if err := c.collectPodLogs(namespace, logDir, scriptLog); err != nil {
-		fmt.Printf("⚠️  Warning: Error collecting pod logs: %v\n", err)
-		fmt.Fprintf(scriptLog, "Warning: Error collecting pod logs: %v\n", err)
+	if c.opts.SkipLogs {
+		console.Println("⏭️  Skipping pod logs (--skip-logs)")
+		fmt.Fprintln(scriptLog, "=== Skipping Pod Logs ===")
+	} else {
+		console.Println("📋 === Collecting Pod Logs ===")
+		fmt.Fprintln(scriptLog, "=== Collecting Pod Logs ===")
+		phaseStart := time.Now()
+		if err := c.collectPodLogs(namespace, logDir, scriptLog, timings, summary); err != nil {
+			console.Printf("⚠️  Warning: Error collecting pod logs: %v\n", err)
+			fmt.Fprintf(scriptLog, "Warning: Error collecting pod logs: %v\n", err)
+			c.recordFailure("pod logs", err, nil)
+		}
+		podLogsDuration = timings.record("pod logs (total)", phaseStart)
 	}
 
 	// Collect additional information based on namespace
-	fmt.Println("\n📊 === Collecting Additional Information ===")
-	fmt.Fprintln(scriptLog, "\n=== Collecting Additional Information ===")
-	if err := c.collectAdditionalInfo(namespace, logDir, scriptLog); err != nil {
-		fmt.Printf("⚠️  Warning: Error collecting additional info: %v\n", err)
-		fmt.Fprintf(scriptLog, "Warning: Error collecting additional info: %v\n", err)
+	if c.opts.LogsOnly {
+		console.Println("\n⏭️  Skipping additional information (--logs-only)")
+		fmt.Fprintln(scriptLog, "\n=== Skipping Additional Information ===")
+	} else {
+		console.Println("\n📊 === Collecting Additional Information ===")
+		fmt.Fprintln(scriptLog, "\n=== Collecting Additional Information ===")
+		phaseStart := time.Now()
+		if err := c.collectAdditionalInfo(namespace, logDir, scriptLog); err != nil {
+			console.Printf("⚠️  Warning: Error collecting additional info: %v\n", err)
+			fmt.Fprintf(scriptLog, "Warning: Error collecting additional info: %v\n", err)
+			c.recordFailure("additional info", err, nil)
+		}
+		additionalInfoDuration = timings.record("additional info", phaseStart)
+	}
+
+	// Give items that failed transiently one more chance before archiving
+	phaseStart := time.Now()
+	c.retryFailed(scriptLog)
+	timings.record("retry failed items", phaseStart)
+
+	// What still failed makes the collection incomplete
+	for _, item := range c.takeFailures() {
+		summary.failures = append(summary.failures, item.name)
+	}
+	if len(summary.failures) > 0 {
+		fmt.Fprintf(scriptLog, "\nIncomplete: %d item(s) could not be collected: %s\n", len(summary.failures), strings.Join(summary.failures, ", "))
+	}
+
+	if err := c.writeCommands(filepath.Join(logDir, "commands.txt")); err != nil {
+		console.Printf("⚠️  Warning: Failed to write commands: %v\n", err)
+		fmt.Fprintf(scriptLog, "Warning: Failed to write commands: %v\n", err)
+	}
+
+	// The archive phase cannot time itself inside the archive, so it is reported on the console
+	if err := timings.write(filepath.Join(logDir, "timings.txt")); err != nil {
+		console.Printf("⚠️  Warning: Failed to write timings: %v\n", err)
 	}
 
 	// Create archive
-	fmt.Println("\n📦 === Creating Archive ===")
+	console.Println("\n📦 === Creating Archive ===")
 	fmt.Fprintln(scriptLog, "\n=== Creating Archive ===")
-	if err := c.createArchive(logDir, archiveName, scriptLog); err != nil {
-		return fmt.Errorf("failed to create archive: %w", err)
+	archiveStart := time.Now()
+	entries, err := c.createArchive(logDir, archiveName, scriptLog)
+	if err != nil {
+		os.Remove(archiveName)
+		c.emit(progressEvent{Phase: "archive", Namespace: namespace, Item: archiveName}, err)
+		return fmt.Errorf("failed to create archive, keeping %s: %w", logDir, err)
+	}
+
+	// Verify the archive before removing the source data
+	err = c.verifyArchive(archiveName, entries)
+	c.emit(progressEvent{Phase: "archive", Namespace: namespace, Item: archiveName}, err)
+	if err != nil {
+		fmt.Fprintf(scriptLog, "  ⚠ Archive verification failed: %v\n", err)
+		return fmt.Errorf("archive verification failed, keeping %s: %w", logDir, err)
 	}
+	console.Printf("  ✅ Archive verified (%d entries)\n", entries)
+	console.Printf("⏱️  Namespace %s: pod logs %s, additional info %s, archive %s\n", namespace,
+		podLogsDuration.Round(time.Millisecond), additionalInfoDuration.Round(time.Millisecond), time.Since(archiveStart).Round(time.Millisecond))
 
 	// Clean up temp directory
+	if c.opts.KeepDir {
+		console.Printf("📁 Keeping collection directory %s (--keep-dir)\n", logDir)
+		return nil
+	}
 	if err := os.RemoveAll(logDir); err != nil {
-		fmt.Printf("Warning: Failed to clean up temp directory: %v\n", err)
+		console.Printf("Warning: Failed to clean up temp directory: %v\n", err)
 	}
 
 	return nil
@@ -338,271 +779,844 @@ func (c *Collector) writeScriptLogHeader(w io.Writer, namespace, clusterURL, cpU
 	fmt.Fprintf(w, "Namespace: %s\n", namespace)
 	fmt.Fprintf(w, "Cluster URL: %s\n", clusterURL)
 	fmt.Fprintf(w, "Control Plane URL: %s\n", cpURL)
+	fmt.Fprintf(w, "Log window: %s\n", c.opts.logWindow())
+	if c.opts.NoTimestamps {
+		fmt.Fprintln(w, "Log timestamps: disabled (--no-timestamps)")
+	} else {
+		fmt.Fprintln(w, "Log timestamps: enabled")
+	}
 	fmt.Fprintln(w, "")
 }
 
 // collectPodLogs collects logs from all pods in the namespace
-func (c *Collector) collectPodLogs(namespace, logDir string, scriptLog io.Writer) error {
+func (c *Collector) collectPodLogs(namespace, logDir string, scriptLog io.Writer, timings *phaseTimings, summary *namespaceSummary) error {
 	logsSubDir := filepath.Join(logDir, "logs")
 	if err := os.MkdirAll(logsSubDir, 0755); err != nil {
 		return err
 	}
 
-	fmt.Printf("  📋 Collecting pod information for namespace: %s\n", namespace)
+	console.Printf("  📋 Collecting pod information for namespace: %s\n", namespace)
 	fmt.Fprintf(scriptLog, "  Collecting pod information for namespace: %s\n", namespace)
 
 	// Get all pods in namespace
-	pods, err := c.getPods(namespace)
+	pods, err := c.getLogPods(namespace)
 	if err != nil {
 		return err
 	}
 	if len(pods) == 0 {
-		fmt.Printf("  ❌ No pods found in namespace: %s\n", namespace)
+		if c.opts.CrashingOnly {
+			console.Printf("  ✅ No crashing pods found in namespace: %s\n", namespace)
+			fmt.Fprintf(scriptLog, "  No crashing pods found in namespace: %s\n", namespace)
+			return nil
+		}
+		console.Printf("  ❌ No pods found in namespace: %s\n", namespace)
 		fmt.Fprintf(scriptLog, "  No pods found in namespace: %s\n", namespace)
 		return nil
 	}
 
-	fmt.Printf("  ✅ Found %d pods in namespace: %s\n", len(pods), namespace)
+	console.Printf("  ✅ Found %d pods in namespace: %s\n", len(pods), namespace)
 	fmt.Fprintf(scriptLog, "  Found %d pods in namespace: %s\n", len(pods), namespace)
 
+	// Collect leader-election leaders first and label operator replicas by role
+	roles := map[string]string{}
+	if holders, err := c.getRunAILeaseHolders(namespace); err == nil && len(holders) > 0 {
+		roles = leaderRoles(pods, holders)
+		pods = leadersFirst(pods, roles)
+		if err := writeLeaderElection(filepath.Join(logDir, "leader-election.txt"), holders, roles); err != nil {
+			fmt.Fprintf(scriptLog, "  Warning: Failed to write leader election info: %v\n", err)
+		}
+	}
+
 	for i, pod := range pods {
-		fmt.Printf("  🔄 [%d/%d] Processing pod: %s\n", i+1, len(pods), pod)
-		fmt.Fprintf(scriptLog, "  Processing pod: %s\n", pod)
+		label := pod
+		if role, exists := roles[pod]; exists {
+			label = fmt.Sprintf("%s (%s)", pod, role)
+		}
+		console.Printf("  🔄 [%d/%d] Processing pod: %s\n", i+1, len(pods), label)
+		fmt.Fprintf(scriptLog, "  Processing pod: %s\n", label)
+		podStart := time.Now()
+		summary.pods++
 
 		// Get containers for this pod
 		containers, initContainers, err := c.getPodContainers(namespace, pod)
 		if err != nil {
-			fmt.Printf("    ⚠️  Warning: Failed to get containers for pod: %s\n", pod)
+			c.emit(progressEvent{Phase: "podlogs", Namespace: namespace, Pod: pod}, err)
+			console.Printf("    ⚠️  Warning: Failed to get containers for pod: %s\n", pod)
 			fmt.Fprintf(scriptLog, "    Warning: Failed to get containers for pod: %s\n", pod)
+			c.recordFailure(fmt.Sprintf("containers of %s", pod), err, nil)
 			continue
 		}
-		fmt.Printf("    📦 Regular containers found: %d\n", len(containers))
+
+		// Sidecars such as istio-proxy are skipped unless --include-all-containers is set
+		containers, skipped := c.filterContainers(containers)
+		initContainers, skippedInit := c.filterContainers(initContainers)
+		skipped = append(skipped, skippedInit...)
+		if len(skipped) > 0 {
+			console.Printf("    ⏭️  Skipping excluded containers: %s\n", strings.Join(skipped, ", "))
+			fmt.Fprintf(scriptLog, "    Skipped excluded containers (--exclude-containers): %s\n", strings.Join(skipped, ", "))
+		}
+		console.Printf("    📦 Regular containers found: %d\n", len(containers))
 		fmt.Fprintf(scriptLog, "    Regular containers found: %d\n", len(containers))
 		if len(initContainers) > 0 {
-			fmt.Printf("    🚀 Init containers found: %d\n", len(initContainers))
+			console.Printf("    🚀 Init containers found: %d\n", len(initContainers))
 		}
 		fmt.Fprintf(scriptLog, "    Init containers found: %d\n", len(initContainers))
 
+		// Crashing pods get a container state summary next to their logs
+		if c.opts.CrashingOnly {
+			stateFile := filepath.Join(logsSubDir, fmt.Sprintf("%s_state.txt", pod))
+			if err := c.collectContainerStates(namespace, pod, stateFile); err != nil {
+				console.Printf("    ⚠️  Warning: Failed to collect container states for pod: %s\n", pod)
+				fmt.Fprintf(scriptLog, "    Warning: Failed to collect container states for pod: %s\n", pod)
+			} else {
+				fmt.Fprintf(scriptLog, "    ✓ Container states saved to: %s\n", stateFile)
+			}
+		}
+
 		// Collect logs for regular containers
 		for j, container := range containers {
 			logFile := filepath.Join(logsSubDir, fmt.Sprintf("%s_%s.log", pod, container))
-			fmt.Printf("    📋 [%d/%d] Collecting logs: %s/%s\n", j+1, len(containers), pod, container)
+			console.Printf("    📋 [%d/%d] Collecting logs: %s/%s\n", j+1, len(containers), pod, container)
 			fmt.Fprintf(scriptLog, "    Collecting logs for Pod: %s, Container: %s\n", pod, container)
 
-			if err := c.collectContainerLogs(pod, container, namespace, logFile, false); err != nil {
-				fmt.Printf("      ⚠️  Warning: Failed to collect logs for container: %s\n", container)
+			savedFile, err := c.collectContainerLogs(pod, container, namespace, logFile, false)
+			c.emit(progressEvent{Phase: "podlogs", Namespace: namespace, Pod: pod, Container: container}, err)
+			if err != nil {
+				console.Printf("      ⚠️  Warning: Failed to collect logs for container: %s\n", container)
 				fmt.Fprintf(scriptLog, "      ⚠ Warning: Failed to collect logs for container: %s\n", container)
+				c.recordFailure(fmt.Sprintf("logs for %s/%s", pod, container), err, c.retryContainerLogs(pod, container, namespace, logFile, false, summary))
 			} else {
-				fmt.Printf("      ✅ Logs saved\n")
-				fmt.Fprintf(scriptLog, "      ✓ Logs saved to: %s\n", logFile)
+				summary.containers++
+				console.Printf("      ✅ Logs saved\n")
+				fmt.Fprintf(scriptLog, "      ✓ Logs saved to: %s\n", savedFile)
+			}
+
+			if c.opts.CrashingOnly {
+				c.collectPreviousLogs(pod, container, namespace, filepath.Join(logsSubDir, fmt.Sprintf("%s_%s_previous.log", pod, container)), scriptLog)
+			}
+			if window := c.opts.restartWindow(); window > 0 {
+				c.collectRestartLog(pod, container, namespace, filepath.Join(logsSubDir, fmt.Sprintf("%s_%s_restart.log", pod, container)), window, scriptLog)
 			}
 		}
 
 		// Collect logs for init containers
 		for j, container := range initContainers {
 			logFile := filepath.Join(logsSubDir, fmt.Sprintf("%s_%s_init.log", pod, container))
-			fmt.Printf("    🚀 [%d/%d] Collecting init logs: %s/%s\n", j+1, len(initContainers), pod, container)
+			console.Printf("    🚀 [%d/%d] Collecting init logs: %s/%s\n", j+1, len(initContainers), pod, container)
 			fmt.Fprintf(scriptLog, "    Collecting logs for Pod: %s, Init Container: %s\n", pod, container)
 
-			if err := c.collectContainerLogs(pod, container, namespace, logFile, true); err != nil {
-				fmt.Printf("      ⚠️  Warning: Failed to collect logs for init container: %s\n", container)
+			savedFile, err := c.collectContainerLogs(pod, container, namespace, logFile, true)
+			c.emit(progressEvent{Phase: "podlogs", Namespace: namespace, Pod: pod, Container: container, Item: "init"}, err)
+			if err != nil {
+				console.Printf("      ⚠️  Warning: Failed to collect logs for init container: %s\n", container)
 				fmt.Fprintf(scriptLog, "      ⚠ Warning: Failed to collect logs for init container: %s\n", container)
+				c.recordFailure(fmt.Sprintf("init logs for %s/%s", pod, container), err, c.retryContainerLogs(pod, container, namespace, logFile, true, summary))
 			} else {
-				fmt.Printf("      ✅ Init logs saved\n")
-				fmt.Fprintf(scriptLog, "      ✓ Init container logs saved to: %s\n", logFile)
+				summary.containers++
+				console.Printf("      ✅ Init logs saved\n")
+				fmt.Fprintf(scriptLog, "      ✓ Init container logs saved to: %s\n", savedFile)
+			}
+
+			if c.opts.CrashingOnly {
+				c.collectPreviousLogs(pod, container, namespace, filepath.Join(logsSubDir, fmt.Sprintf("%s_%s_init_previous.log", pod, container)), scriptLog)
+			}
+			if window := c.opts.restartWindow(); window > 0 {
+				c.collectRestartLog(pod, container, namespace, filepath.Join(logsSubDir, fmt.Sprintf("%s_%s_init_restart.log", pod, container)), window, scriptLog)
 			}
 		}
+
+		timings.record(fmt.Sprintf("pod logs: %s", pod), podStart)
 	}
 
 	return nil
 }
 
-// collectContainerLogs collects logs from a specific container
-func (c *Collector) collectContainerLogs(pod, container, namespace, logFile string, isInit bool) error {
-	output, err := c.getPodLogsForContainer(namespace, pod, container)
-	if err != nil {
-		return err
+// collectContainerLogs collects logs from a specific container and returns the file written.
+// JSON-lines logs are written as an indented .json file when PrettyJSON is set.
+func (c *Collector) collectContainerLogs(pod, container, namespace, logFile string, isInit bool) (string, error) {
+	if c.opts.Resume != "" {
+		if existing, found := collectedLogFile(logFile); found {
+			console.Printf("      ⏭️  Already collected: %s\n", filepath.Base(existing))
+			return existing, nil
+		}
 	}
 
-	return os.WriteFile(logFile, []byte(output), 0644)
-}
-
-// collectAdditionalInfo collects namespace-specific additional information
-func (c *Collector) collectAdditionalInfo(namespace, logDir string, scriptLog io.Writer) error {
-	switch namespace {
-	case "runai":
-		return c.collectRunaiInfo(logDir, scriptLog)
-	case "runai-backend":
-		return c.collectBackendInfo(logDir, scriptLog)
+	output, err := c.getPodLogsForContainer(namespace, pod, container)
+	if err != nil {
+		return "", err
 	}
-	return nil
-}
 
-// collectRunaiInfo collects information specific to the runai namespace
-func (c *Collector) collectRunaiInfo(logDir string, scriptLog io.Writer) error {
-	actions := []struct {
-		name     string
-		filename string
-		cmd      func() (string, error)
-	}{
-		{"Helm releases info", "helm_releases_info.txt", func() (string, error) {
-			return c.getHelmReleasesInfo()
-		}},
-		{"ConfigMap runai-public", "cm_runai-public.yaml", func() (string, error) {
-			return c.getConfigMap("runai", "runai-public")
-		}},
-		{"Pod list for runai namespace", "pod-list_runai.txt", func() (string, error) {
-			return c.getPodsWide("runai")
-		}},
-		{"Node list", "node-list.txt", func() (string, error) {
-			return c.getNodesWide()
-		}},
-		{"RunAI config", "runaiconfig.yaml", func() (string, error) {
-			return c.getResourceAsYAML("runai", "runaiconfig", "runai")
-		}},
-		{"Engine config", "engine-config.yaml", func() (string, error) {
-			return c.getResourceAsYAML("runai", "configs.engine.run.ai", "engine-config")
-		}},
+	if c.grepPattern != nil {
+		filteredFile := strings.TrimSuffix(logFile, ".log") + ".filtered.log"
+		var filtered strings.Builder
+		if err := grepLines(strings.NewReader(output), &filtered, c.grepPattern, c.opts.GrepContext); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filteredFile, []byte(filtered.String()), 0644); err != nil {
+			return "", err
+		}
+		if c.opts.GrepOnly {
+			return filteredFile, nil
+		}
 	}
 
-	for i, action := range actions {
-		fmt.Printf("  📊 [%d/%d] Collecting %s...\n", i+1, len(actions), action.name)
-		fmt.Fprintf(scriptLog, "Collecting %s...\n", action.name)
-		output, err := action.cmd()
+	if c.opts.PrettyJSON && looksLikeJSONLines(output, !c.opts.NoTimestamps) {
+		jsonFile := strings.TrimSuffix(logFile, ".log") + ".json"
+		pretty, err := prettyJSONLines(output, !c.opts.NoTimestamps)
 		if err != nil {
-			fmt.Printf("    ⚠️  Warning: Failed to collect %s: %v\n", action.name, err)
-			fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to collect %s: %v\n", action.name, err)
-			continue
-		}
-
-		filePath := filepath.Join(logDir, action.filename)
-		if err := os.WriteFile(filePath, []byte(output), 0644); err != nil {
-			fmt.Printf("    ⚠️  Warning: Failed to write %s: %v\n", action.filename, err)
-			fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to write %s: %v\n", action.filename, err)
-			continue
+			return "", err
 		}
+		return jsonFile, os.WriteFile(jsonFile, pretty, 0644)
+	}
 
-		fmt.Printf("    ✅ %s saved\n", action.name)
-		fmt.Fprintf(scriptLog, "  ✓ %s saved\n", action.name)
+	if !c.opts.Dedup {
+		return logFile, os.WriteFile(logFile, []byte(output), 0644)
 	}
 
-	return nil
-}
+	file, err := os.Create(logFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
 
-// collectBackendInfo collects information specific to the runai-backend namespace
-func (c *Collector) collectBackendInfo(logDir string, scriptLog io.Writer) error {
-	actions := []struct {
-		name     string
-		filename string
-		cmd      func() (string, error)
-	}{
-		{"Pod list for runai-backend namespace", "pod-list_runai-backend.txt", func() (string, error) {
-			return c.getPodsWide("runai-backend")
-		}},
-		{"Helm releases info (backend)", "helm_releases_info_backend.txt", func() (string, error) {
-			return c.getHelmReleasesInfoNamespace("runai-backend")
-		}},
+	if err := dedupLines(strings.NewReader(output), file, !c.opts.NoTimestamps); err != nil {
+		return "", err
 	}
+	return logFile, file.Close()
+}
 
-	for i, action := range actions {
-		fmt.Printf("  📊 [%d/%d] Collecting %s...\n", i+1, len(actions), action.name)
-		fmt.Fprintf(scriptLog, "Collecting %s...\n", action.name)
-		output, err := action.cmd()
-		if err != nil {
-			fmt.Printf("    ⚠️  Warning: Failed to collect %s: %v\n", action.name, err)
-			fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to collect %s: %v\n", action.name, err)
-			continue
+// filterContainers splits container names into those to collect and those excluded by
+// the --exclude-containers patterns
+func (c *Collector) filterContainers(containers []string) ([]string, []string) {
+	patterns := c.opts.excludedContainers()
+	var kept, skipped []string
+	for _, container := range containers {
+		if matchesAnyPattern(container, patterns) {
+			skipped = append(skipped, container)
+		} else {
+			kept = append(kept, container)
 		}
+	}
+	return kept, skipped
+}
 
-		filePath := filepath.Join(logDir, action.filename)
-		if err := os.WriteFile(filePath, []byte(output), 0644); err != nil {
-			fmt.Printf("    ⚠️  Warning: Failed to write %s: %v\n", action.filename, err)
-			fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to write %s: %v\n", action.filename, err)
-			continue
+// collectedLogFile returns the non-empty file an earlier run saved for logFile, which may
+// have been rewritten as .json, filtered by --grep-only or compressed to .log.gz
+func collectedLogFile(logFile string) (string, bool) {
+	base := strings.TrimSuffix(logFile, ".log")
+	for _, candidate := range []string{logFile, base + ".json", logFile + ".gz", base + ".filtered.log", base + ".filtered.log.gz"} {
+		if info, err := os.Stat(candidate); err == nil && info.Mode().IsRegular() && info.Size() > 0 {
+			return candidate, true
 		}
-
-		fmt.Printf("    ✅ %s saved\n", action.name)
-		fmt.Fprintf(scriptLog, "  ✓ %s saved\n", action.name)
 	}
-
-	return nil
+	return "", false
 }
 
-// createArchive creates a tar.gz archive of the log directory
-func (c *Collector) createArchive(logDir, archiveName string, scriptLog io.Writer) error {
-	fmt.Printf("  📦 Creating archive %s...\n", archiveName)
-	fmt.Fprintf(scriptLog, "Creating tar archive...\n")
-
-	// Create the archive file
-	archiveFile, err := os.Create(archiveName)
+// resumeNamespace reads the collected namespace from the script log of an interrupted run
+func resumeNamespace(dir string) (string, error) {
+	file, err := os.Open(filepath.Join(dir, "script.log"))
 	if err != nil {
-		return err
+		return "", fmt.Errorf("cannot resume %s: %w", dir, err)
 	}
-	defer archiveFile.Close()
-
-	// Create gzip writer
-	gzipWriter := gzip.NewWriter(archiveFile)
-	defer gzipWriter.Close()
+	defer file.Close()
 
-	// Create tar writer
-	tarWriter := tar.NewWriter(gzipWriter)
-	defer tarWriter.Close()
-
-	// Walk the directory and add files to archive
-	err = filepath.Walk(logDir, func(file string, fi os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if namespace, found := strings.CutPrefix(scanner.Text(), "Namespace: "); found {
+			return strings.TrimSpace(namespace), nil
 		}
+	}
+	return "", fmt.Errorf("cannot resume %s: no namespace found in script.log", dir)
+}
 
-		// Create tar header
-		header, err := tar.FileInfoHeader(fi, file)
-		if err != nil {
-			return err
+// jsonDetectionLines is how many non-empty lines must parse as JSON objects for
+// a log to be treated as JSON lines
+const jsonDetectionLines = 5
+
+// splitLogTimestamp separates the RFC3339 timestamp prefix from a log line
+func splitLogTimestamp(line string, hasTimestamps bool) (string, string) {
+	if !hasTimestamps {
+		return "", line
+	}
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		return line[:i], line[i+1:]
+	}
+	return "", line
+}
+
+// looksLikeJSONLines reports whether the first non-empty log lines are all JSON objects
+func looksLikeJSONLines(output string, hasTimestamps bool) bool {
+	checked := 0
+	for _, line := range strings.Split(output, "\n") {
+		_, message := splitLogTimestamp(strings.TrimSpace(line), hasTimestamps)
+		if message == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(message), &entry); err != nil {
+			return false
 		}
+		checked++
+		if checked == jsonDetectionLines {
+			break
+		}
+	}
+	return checked > 0
+}
 
-		// Update the name to maintain directory structure
-		header.Name = file
+// prettyJSONLines converts JSON-lines logs into an indented JSON array with sorted keys.
+// The log timestamp is kept as "_timestamp"; lines that are not JSON objects are kept as strings.
+func prettyJSONLines(output string, hasTimestamps bool) ([]byte, error) {
+	entries := []interface{}{}
+	for _, line := range strings.Split(output, "\n") {
+		timestamp, message := splitLogTimestamp(strings.TrimSpace(line), hasTimestamps)
+		if message == "" {
+			continue
+		}
 
-		// Write header
-		if err := tarWriter.WriteHeader(header); err != nil {
-			return err
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(message), &entry); err != nil {
+			entries = append(entries, strings.TrimSpace(line))
+			continue
+		}
+		if _, exists := entry["_timestamp"]; !exists && timestamp != "" {
+			entry["_timestamp"] = timestamp
 		}
+		entries = append(entries, entry)
+	}
 
-		// If it's a file, write the content
-		if !fi.IsDir() {
-			data, err := os.Open(file)
-			if err != nil {
+	// encoding/json sorts map keys, which gives a stable key order
+	return json.MarshalIndent(entries, "", "  ")
+}
+
+// grepLines copies the lines of r matching pattern to w, with up to context lines before
+// and after each match. With context, non-adjacent groups are separated by "--", as grep does.
+func grepLines(r io.Reader, w io.Writer, pattern *regexp.Regexp, context int) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var before []string
+	after := 0
+	lastWritten, lineNumber := 0, 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		if pattern.MatchString(line) {
+			if context > 0 && lastWritten > 0 && lineNumber-len(before) > lastWritten+1 {
+				if _, err := fmt.Fprintln(w, "--"); err != nil {
+					return err
+				}
+			}
+			for _, previous := range before {
+				if _, err := fmt.Fprintln(w, previous); err != nil {
+					return err
+				}
+			}
+			before = before[:0]
+			if _, err := fmt.Fprintln(w, line); err != nil {
 				return err
 			}
-			defer data.Close()
+			lastWritten, after = lineNumber, context
+			continue
+		}
 
-			_, err = io.Copy(tarWriter, data)
-			if err != nil {
+		if after > 0 {
+			if _, err := fmt.Fprintln(w, line); err != nil {
 				return err
 			}
+			lastWritten = lineNumber
+			after--
+			continue
 		}
 
-		return nil
-	})
+		if context > 0 {
+			if len(before) == context {
+				before = before[1:]
+			}
+			before = append(before, line)
+		}
+	}
+	return scanner.Err()
+}
+
+// dedupLines copies lines from r to w, collapsing runs of identical consecutive lines
+// into the first line followed by "(repeated N times)". With hasTimestamps, the leading
+// timestamp field is ignored when comparing lines.
+func dedupLines(r io.Reader, w io.Writer, hasTimestamps bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
 
+	var prevLine, prevKey string
+	count := 0
+	flush := func() error {
+		var err error
+		switch {
+		case count > 1:
+			_, err = fmt.Fprintf(w, "%s (repeated %d times)\n", prevLine, count)
+		case count == 1:
+			_, err = fmt.Fprintln(w, prevLine)
+		}
+		return err
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		key := line
+		if hasTimestamps {
+			if i := strings.IndexByte(line, ' '); i >= 0 {
+				key = line[i+1:]
+			}
+		}
+
+		if count > 0 && key == prevKey {
+			count++
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		prevLine, prevKey, count = line, key, 1
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// collectPreviousLogs collects logs from the previous instance of a restarted container
+func (c *Collector) collectPreviousLogs(pod, container, namespace, logFile string, scriptLog io.Writer) {
+	output, err := c.getPreviousPodLogsForContainer(namespace, pod, container)
+	if err == nil {
+		err = os.WriteFile(logFile, []byte(output), 0644)
+	}
+	if err != nil {
+		// Containers that never restarted have no previous instance
+		console.Printf("      ℹ️  No previous logs for container: %s\n", container)
+		fmt.Fprintf(scriptLog, "      No previous logs for container %s: %v\n", container, err)
+		return
+	}
+
+	console.Printf("      ✅ Previous logs saved\n")
+	fmt.Fprintf(scriptLog, "      ✓ Previous logs saved to: %s\n", logFile)
+}
+
+// collectContainerStates writes a summary of the state of every container in a pod
+func (c *Collector) collectContainerStates(namespace, podName, stateFile string) error {
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Container states for pod %s (phase: %s)\n\n", pod.Name, pod.Status.Phase))
+	output.WriteString("CONTAINER\tTYPE\tREADY\tRESTARTS\tSTATE\tREASON\tEXIT CODE\tLAST STATE\tLAST REASON\tLAST EXIT CODE\n")
+
+	writeStatuses := func(containerType string, statuses []corev1.ContainerStatus) {
+		for _, status := range statuses {
+			state, reason, exitCode := describeContainerState(status.State)
+			lastState, lastReason, lastExitCode := describeContainerState(status.LastTerminationState)
+			output.WriteString(fmt.Sprintf("%s\t%s\t%t\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				status.Name, containerType, status.Ready, status.RestartCount,
+				state, reason, exitCode, lastState, lastReason, lastExitCode))
+		}
+	}
+	writeStatuses("init", pod.Status.InitContainerStatuses)
+	writeStatuses("regular", pod.Status.ContainerStatuses)
+
+	return os.WriteFile(stateFile, []byte(output.String()), 0644)
+}
+
+// describeContainerState flattens a container state into state, reason and exit code columns
+func describeContainerState(state corev1.ContainerState) (string, string, string) {
+	switch {
+	case state.Waiting != nil:
+		return "Waiting", state.Waiting.Reason, "-"
+	case state.Running != nil:
+		return "Running", "-", "-"
+	case state.Terminated != nil:
+		return "Terminated", state.Terminated.Reason, fmt.Sprintf("%d", state.Terminated.ExitCode)
+	}
+	return "-", "-", "-"
+}
+
+// collectAdditionalInfo collects namespace-specific additional information
+func (c *Collector) collectAdditionalInfo(namespace, logDir string, scriptLog io.Writer) error {
+	if c.opts.IncludeNamespaceYAML {
+		c.collectNamespaceYAML(namespace, logDir, scriptLog)
+	}
+
+	c.collectQuotaInfo(namespace, logDir, scriptLog)
+	// The pod list is fetched once for every step deriving its output from the pods
+	pods := c.cachedPods(namespace)
+	c.collectImagePullInfo(namespace, pods, logDir, scriptLog)
+	c.collectProbes(namespace, pods, logDir, scriptLog)
+	c.collectExtraResources(namespace, logDir, scriptLog)
+
+	// The cluster namespace is the one RunAI is installed in, which is not always "runai"
+	runaiNamespace := c.findRunAINamespace()
+	if namespace == runaiNamespace || namespace == "runai-backend" {
+		c.collectRBACInfo(namespace, logDir, scriptLog)
+		c.collectOpenShiftInfo(namespace, logDir, scriptLog)
+	}
+
+	switch namespace {
+	case runaiNamespace:
+		return c.collectRunaiInfo(namespace, pods, logDir, scriptLog)
+	case "runai-backend":
+		return c.collectBackendInfo(pods, logDir, scriptLog)
+	}
+	return nil
+}
+
+// collectNamespaceYAML writes the Namespace object without managed fields to namespace.yaml
+func (c *Collector) collectNamespaceYAML(namespace, logDir string, scriptLog io.Writer) {
+	console.Printf("  📊 Collecting Namespace object...\n")
+	fmt.Fprintf(scriptLog, "Collecting Namespace object...\n")
+
+	c.runAction(namespace, logDir, scriptLog, collectAction{"Namespace object", "namespace.yaml", func() (string, error) {
+		ns, err := c.clientset.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		ns.ManagedFields = nil
+		return c.objectToYAML(ns)
+	}})
+}
+
+// collectAction is a collection step writing the output of cmd to filename
+type collectAction struct {
+	name     string
+	filename string
+	cmd      func() (string, error)
+}
+
+// runActions runs collection actions in order, writing their output to logDir
+func (c *Collector) runActions(namespace, logDir string, scriptLog io.Writer, actions []collectAction) {
+	for i, action := range actions {
+		console.Printf("  📊 [%d/%d] Collecting %s...\n", i+1, len(actions), action.name)
+		fmt.Fprintf(scriptLog, "Collecting %s...\n", action.name)
+		c.runAction(namespace, logDir, scriptLog, action)
+	}
+}
+
+// runAction runs one collection action and writes its output to logDir. The step is
+// reported as a progress event, and a failure is recorded so the namespace is reported
+// as incomplete and the action is retried.
+func (c *Collector) runAction(namespace, logDir string, scriptLog io.Writer, action collectAction) {
+	filePath := filepath.Join(logDir, action.filename)
+	output, err := action.cmd()
+	if err != nil {
+		c.failStep(namespace, action.filename, action.name, scriptLog, err, func() error {
+			return writeActionOutput(action.cmd, filePath)
+		})
+		return
+	}
+
+	err = os.WriteFile(filePath, []byte(output), 0644)
+	c.emit(progressEvent{Phase: "resources", Namespace: namespace, Item: action.filename}, err)
+	if err != nil {
+		console.Printf("    ⚠️  Warning: Failed to write %s: %v\n", action.filename, err)
+		fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to write %s: %v\n", action.filename, err)
+		c.recordFailure(action.name, err, nil)
+		return
+	}
+
+	console.Printf("    ✅ %s saved\n", action.name)
+	fmt.Fprintf(scriptLog, "  ✓ %s saved\n", action.name)
+}
+
+// failStep reports a collection step that failed before writing its file, and records
+// it with retry
+func (c *Collector) failStep(namespace, item, name string, scriptLog io.Writer, err error, retry func() error) {
+	c.emit(progressEvent{Phase: "resources", Namespace: namespace, Item: item}, err)
+	console.Printf("    ⚠️  Warning: Failed to collect %s: %v\n", name, err)
+	fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to collect %s: %v\n", name, err)
+	c.recordFailure(name, err, retry)
+}
+
+// collectRunaiInfo collects information specific to the namespace RunAI is installed in
+func (c *Collector) collectRunaiInfo(namespace string, pods func() ([]corev1.Pod, error), logDir string, scriptLog io.Writer) error {
+	actions := []collectAction{
+		{"Helm releases info", "helm_releases_info.txt", func() (string, error) {
+			return c.getHelmReleasesInfo()
+		}},
+		{"ConfigMap runai-public", "cm_runai-public.yaml", func() (string, error) {
+			return c.getConfigMap(namespace, "runai-public")
+		}},
+		{"Pod list for runai namespace", "pod-list_runai.txt", func() (string, error) {
+			return c.getPodsWide(pods)
+		}},
+		{"Pod health for runai namespace", "pod-health.txt", func() (string, error) {
+			return c.getPodHealth(pods)
+		}},
+		{"PodDisruptionBudgets", "pdbs.yaml", func() (string, error) {
+			return c.getPodDisruptionBudgetsYAML(namespace)
+		}},
+		{"PodDisruptionBudgets summary", "pdb.txt", func() (string, error) {
+			return c.getPodDisruptionBudgetsSummary(namespace)
+		}},
+		{"Node list", "node-list.txt", func() (string, error) {
+			return c.getNodesWide()
+		}},
+		{"Node runtime details", "node-runtime.txt", func() (string, error) {
+			return c.getNodeRuntime()
+		}},
+		{"RunAI config", "runaiconfig.yaml", func() (string, error) {
+			return c.getRunAIConfigYAML()
+		}},
+		{"Engine config", "engine-config.yaml", func() (string, error) {
+			return c.getResourceAsYAML(namespace, "configs.engine.run.ai", "engine-config")
+		}},
+		{"Engine config summary", "engine-config-summary.txt", func() (string, error) {
+			return c.getEngineConfigSummary(namespace)
+		}},
+		{"Webhook configurations summary", "webhooks.txt", func() (string, error) {
+			return c.getWebhooksSummary()
+		}},
+		{"Webhook configurations", "webhook-configurations.yaml", func() (string, error) {
+			return c.getWebhookConfigurationsYAML()
+		}},
+	}
+
+	c.runActions(namespace, logDir, scriptLog, actions)
+
+	c.collectHelmValues(namespace, logDir, scriptLog)
+	if c.opts.HelmHistory {
+		c.collectHelmHistory(namespace, logDir, scriptLog)
+	}
+	c.collectPrometheusInfo(namespace, logDir, scriptLog)
+
+	if c.opts.IncludeDNS {
+		c.collectDNSInfo(logDir, scriptLog)
+	}
+
+	return nil
+}
+
+// collectBackendInfo collects information specific to the runai-backend namespace
+func (c *Collector) collectBackendInfo(pods func() ([]corev1.Pod, error), logDir string, scriptLog io.Writer) error {
+	actions := []collectAction{
+		{"Pod list for runai-backend namespace", "pod-list_runai-backend.txt", func() (string, error) {
+			return c.getPodsWide(pods)
+		}},
+		{"Pod health for runai-backend namespace", "pod-health.txt", func() (string, error) {
+			return c.getPodHealth(pods)
+		}},
+		{"PodDisruptionBudgets", "pdbs.yaml", func() (string, error) {
+			return c.getPodDisruptionBudgetsYAML("runai-backend")
+		}},
+		{"PodDisruptionBudgets summary", "pdb.txt", func() (string, error) {
+			return c.getPodDisruptionBudgetsSummary("runai-backend")
+		}},
+		{"Helm releases info (backend)", "helm_releases_info_backend.txt", func() (string, error) {
+			return c.getHelmReleasesInfoNamespace("runai-backend")
+		}},
+		{"Ingress TLS certificates", "cert.txt", func() (string, error) {
+			return c.getServedCertificates("runai-backend")
+		}},
+	}
+
+	c.runActions("runai-backend", logDir, scriptLog, actions)
+
+	c.collectHelmValues("runai-backend", logDir, scriptLog)
+	if c.opts.HelmHistory {
+		c.collectHelmHistory("runai-backend", logDir, scriptLog)
+	}
+
+	return nil
+}
+
+// createArchive creates an archive of the log directory and returns the number of entries written
+func (c *Collector) createArchive(logDir, archiveName string, scriptLog io.Writer) (int, error) {
+	console.Printf("  📦 Creating archive %s...\n", archiveName)
+	fmt.Fprintf(scriptLog, "Creating %s archive...\n", c.opts.archiveFormat())
+
+	// Individually compressed logs are stored as-is; compressing them again gains nothing
+	compress := true
+	if c.opts.CompressLogsIndividually {
+		if err := c.compressLogFiles(filepath.Join(logDir, "logs")); err != nil {
+			return 0, fmt.Errorf("failed to compress log files: %w", err)
+		}
+		compress = false
+	}
+
+	entries, err := c.writeArchive(logDir, archiveName, compress)
+	if err != nil {
+		return 0, err
+	}
+
 	// Get archive info
 	archiveInfo, err := os.Stat(archiveName)
 	if err == nil {
-		fmt.Printf("  ✅ Archive created: %s (%.2f MB)\n", archiveName, float64(archiveInfo.Size())/1024/1024)
+		console.Printf("  ✅ Archive created: %s (%.2f MB)\n", archiveName, float64(archiveInfo.Size())/1024/1024)
 		fmt.Fprintf(scriptLog, "  ✓ Archive created\n")
 		fmt.Fprintf(scriptLog, "Archive details: %s (%d bytes)\n", archiveName, archiveInfo.Size())
 	}
 
-	fmt.Printf("  🧹 Cleaning up temporary directory...\n")
+	console.Printf("  🧹 Cleaning up temporary directory...\n")
 	fmt.Fprintf(scriptLog, "Cleaning up temporary directory...\n")
 	fmt.Fprintf(scriptLog, "  ✓ Temporary directory will be removed\n")
 	fmt.Fprintf(scriptLog, "=== Log Collection Completed at %s ===\n", time.Now().Format(time.RFC3339))
 	fmt.Fprintf(scriptLog, "Logs and info archived to %s\n", archiveName)
 
+	return entries, nil
+}
+
+// streamArchive copies a finished archive to the archive writer and removes the local file
+func (c *Collector) streamArchive(archiveName string) error {
+	archiveFile, err := os.Open(archiveName)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archiveName)
+	defer os.Remove(archiveName + ".sha256")
+	defer archiveFile.Close()
+
+	_, err = io.Copy(c.archiveWriter, archiveFile)
+	return err
+}
+
+// lastLogTimestamp parses the RFC3339 timestamp prefix of the last line in a log file
+func lastLogTimestamp(path string) (time.Time, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// Only the tail of the file is needed to find the last line
+	const tailSize = 64 * 1024
+	offset := info.Size() - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return time.Time{}, false
+	}
+
+	lines := strings.Split(strings.TrimRight(string(tail), "\n"), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		fields := strings.SplitN(strings.TrimSpace(lines[i]), " ", 2)
+		if fields[0] == "" {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return timestamp, true
+	}
+	return time.Time{}, false
+}
+
+// compressLogFiles replaces every container .log file under dir with a gzipped .log.gz, so
+// single logs can be extracted and decompressed without decompressing the whole archive
+func (c *Collector) compressLogFiles(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(dir, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() || !strings.HasSuffix(file, ".log") {
+			return nil
+		}
+
+		// Keep dating the log by its last log line, as createArchive does for plain logs
+		modTime := fi.ModTime()
+		if !c.opts.NoTimestamps {
+			if lastTimestamp, ok := lastLogTimestamp(file); ok {
+				modTime = lastTimestamp
+			}
+		}
+
+		if err := gzipFile(file, file+".gz", modTime); err != nil {
+			return err
+		}
+		if err := os.Chtimes(file+".gz", modTime, modTime); err != nil {
+			return err
+		}
+		return os.Remove(file)
+	})
+}
+
+// gzipFile writes a gzip-compressed copy of src to dst
+func gzipFile(src, dst string, modTime time.Time) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gzipWriter := gzip.NewWriter(out)
+	gzipWriter.Name = filepath.Base(src)
+	gzipWriter.ModTime = modTime
+	if _, err := io.Copy(gzipWriter, in); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// archiveEntryName returns the slash-separated tar entry name of path relative to root
+func archiveEntryName(root, path string) (string, error) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the archive root %s", path, root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// verifyArchive re-reads an archive end to end and checks it holds the expected number of entries
+func (c *Collector) verifyArchive(archiveName string, expectedEntries int) error {
+	console.Printf("  🔎 Verifying archive %s...\n", archiveName)
+
+	archiveReader, err := openArchive(archiveName, c.opts.archiveFormat())
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer archiveReader.Close()
+
+	entries := 0
+	for {
+		entry, err := archiveReader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("corrupt archive after %d entries: %w", entries, err)
+		}
+		if _, err := io.Copy(io.Discard, archiveReader); err != nil {
+			return fmt.Errorf("failed to read %s from archive: %w", entry.name, err)
+		}
+		entries++
+	}
+
+	if entries != expectedEntries {
+		return fmt.Errorf("archive has %d entries, expected %d", entries, expectedEntries)
+	}
+
 	return nil
 }
 
@@ -610,18 +1624,95 @@ func (c *Collector) createArchive(logDir, archiveName string, scriptLog io.Write
 
 // getPods gets pod names in a namespace
 func (c *Collector) getPods(namespace string) ([]string, error) {
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	pods, err := c.listPods(namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var podNames []string
+	for _, pod := range pods {
+		podNames = append(podNames, pod.Name)
+	}
+	return podNames, nil
+}
+
+// listPods lists pods page by page using the continue token and accumulates the results
+func (c *Collector) listPods(namespace string, listOptions metav1.ListOptions) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	listOptions.Limit = c.opts.PageSize
+	if listOptions.Limit <= 0 {
+		listOptions.Limit = DefaultPageSize
+	}
+	for {
+		page, err := c.clientset.CoreV1().Pods(namespace).List(context.TODO(), listOptions)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, page.Items...)
+		if page.Continue == "" {
+			return pods, nil
+		}
+		listOptions.Continue = page.Continue
+	}
+}
+
+// cachedPods returns a function listing the pods of a namespace on first use and returning
+// the same pods afterwards, so the actions deriving files from them list the pods once. A
+// failed list is tried again on the next call, e.g. in the retry round.
+func (c *Collector) cachedPods(namespace string) func() ([]corev1.Pod, error) {
+	var mu sync.Mutex
+	var pods []corev1.Pod
+	listed := false
+	return func() ([]corev1.Pod, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if listed {
+			return pods, nil
+		}
+		var err error
+		if pods, err = c.listPods(namespace, metav1.ListOptions{}); err != nil {
+			return nil, err
+		}
+		listed = true
+		return pods, nil
+	}
+}
+
+// getLogPods gets names of the pods to collect logs from, honoring the label
+// selector and crashing-only options
+func (c *Collector) getLogPods(namespace string) ([]string, error) {
+	pods, err := c.getPodsWithLabels(namespace, c.opts.LabelSelector)
 	if err != nil {
 		return nil, err
 	}
 
 	var podNames []string
 	for _, pod := range pods.Items {
+		if c.opts.CrashingOnly && !isCrashingPod(&pod) {
+			continue
+		}
 		podNames = append(podNames, pod.Name)
 	}
 	return podNames, nil
 }
 
+// isCrashingPod reports whether any container in the pod is crash looping, has failed or has restarted
+func isCrashingPod(pod *corev1.Pod) bool {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.RestartCount > 0 {
+			return true
+		}
+		if waiting := status.State.Waiting; waiting != nil && (waiting.Reason == "CrashLoopBackOff" || waiting.Reason == "Error") {
+			return true
+		}
+		if terminated := status.State.Terminated; terminated != nil && (terminated.ExitCode != 0 || terminated.Reason == "Error") {
+			return true
+		}
+	}
+	return false
+}
+
 // getPodContainers gets container names for a pod
 func (c *Collector) getPodContainers(namespace, podName string) ([]string, []string, error) {
 	pod, err := c.clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -645,9 +1736,31 @@ func (c *Collector) getPodContainers(namespace, podName string) ([]string, []str
 
 // getPodLogs gets logs for a specific container in a pod
 func (c *Collector) getPodLogsForContainer(namespace, podName, containerName string) (string, error) {
-	logOptions := &corev1.PodLogOptions{
+	return c.streamPodLogs(namespace, podName, &corev1.PodLogOptions{
+		Container:  containerName,
+		Timestamps: !c.opts.NoTimestamps,
+	})
+}
+
+// getPreviousPodLogsForContainer gets logs for the previous instance of a container in a pod
+func (c *Collector) getPreviousPodLogsForContainer(namespace, podName, containerName string) (string, error) {
+	return c.streamPodLogs(namespace, podName, &corev1.PodLogOptions{
 		Container:  containerName,
-		Timestamps: true,
+		Timestamps: !c.opts.NoTimestamps,
+		Previous:   true,
+	})
+}
+
+// streamPodLogs reads pod logs for the given options into a string, applying
+// the configured since/tail limits and redaction rules; a tail set in the options is kept
+func (c *Collector) streamPodLogs(namespace, podName string, logOptions *corev1.PodLogOptions) (string, error) {
+	if c.opts.Since != nil {
+		sinceSeconds := int64(c.opts.Since.Seconds())
+		logOptions.SinceSeconds = &sinceSeconds
+	}
+	logOptions.SinceTime = c.opts.SinceTime
+	if logOptions.TailLines == nil {
+		logOptions.TailLines = c.opts.TailLines
 	}
 
 	req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, logOptions)
@@ -663,13 +1776,21 @@ func (c *Collector) getPodLogsForContainer(namespace, podName, containerName str
 		return "", err
 	}
 
-	return buf.String(), nil
+	return c.redact(buf.String()), nil
 }
 
-// namespaceExists checks if a namespace exists
-func (c *Collector) namespaceExists(namespace string) bool {
+// namespaceExists checks if a namespace exists. A missing namespace returns false
+// with no error; RBAC and connectivity errors are returned so they are not mistaken
+// for a missing namespace.
+func (c *Collector) namespaceExists(namespace string) (bool, error) {
 	_, err := c.clientset.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
-	return err == nil
+	if err == nil {
+		return true, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
 }
 
 // getConfigMap gets a ConfigMap as YAML
@@ -683,8 +1804,8 @@ func (c *Collector) getConfigMap(namespace, name string) (string, error) {
 }
 
 // getPodsWide gets pods in wide format (similar to kubectl get pods -o wide)
-func (c *Collector) getPodsWide(namespace string) (string, error) {
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+func (c *Collector) getPodsWide(listPods func() ([]corev1.Pod, error)) (string, error) {
+	pods, err := listPods()
 	if err != nil {
 		return "", err
 	}
@@ -692,7 +1813,7 @@ func (c *Collector) getPodsWide(namespace string) (string, error) {
 	var output strings.Builder
 	output.WriteString("NAME\tREADY\tSTATUS\tRESTARTS\tAGE\tIP\tNODE\n")
 
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		readyCount := 0
 		totalCount := len(pod.Status.ContainerStatuses)
 		for _, status := range pod.Status.ContainerStatuses {
@@ -767,65 +1888,232 @@ func (c *Collector) getNodesWide() (string, error) {
 		))
 	}
 
-	return output.String(), nil
+	return output.String(), nil
+}
+
+// getRunAIWebhookConfigurations gets the validating and mutating webhook configurations that reference RunAI
+func (c *Collector) getRunAIWebhookConfigurations() ([]admissionregistrationv1.ValidatingWebhookConfiguration, []admissionregistrationv1.MutatingWebhookConfiguration, error) {
+	validating, err := c.clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+	}
+
+	mutating, err := c.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+	}
+
+	var runaiValidating []admissionregistrationv1.ValidatingWebhookConfiguration
+	for _, config := range validating.Items {
+		matched := c.isRunAIReference(config.Name)
+		for _, webhook := range config.Webhooks {
+			matched = matched || c.isRunAIWebhookClient(webhook.ClientConfig)
+		}
+		if matched {
+			runaiValidating = append(runaiValidating, config)
+		}
+	}
+
+	var runaiMutating []admissionregistrationv1.MutatingWebhookConfiguration
+	for _, config := range mutating.Items {
+		matched := c.isRunAIReference(config.Name)
+		for _, webhook := range config.Webhooks {
+			matched = matched || c.isRunAIWebhookClient(webhook.ClientConfig)
+		}
+		if matched {
+			runaiMutating = append(runaiMutating, config)
+		}
+	}
+
+	return runaiValidating, runaiMutating, nil
+}
+
+// isRunAIReference reports whether a resource name refers to RunAI
+func (c *Collector) isRunAIReference(name string) bool {
+	return strings.Contains(strings.ToLower(name), "runai") || strings.Contains(strings.ToLower(name), "run.ai")
+}
+
+// isRunAIWebhookClient reports whether a webhook targets a RunAI service or URL
+func (c *Collector) isRunAIWebhookClient(client admissionregistrationv1.WebhookClientConfig) bool {
+	if client.Service != nil {
+		return containsString(c.opts.Namespaces, client.Service.Namespace) ||
+			c.isRunAIReference(client.Service.Namespace) ||
+			c.isRunAIReference(client.Service.Name)
+	}
+	return client.URL != nil && c.isRunAIReference(*client.URL)
+}
+
+// describeWebhookTarget formats the service or URL a webhook calls
+func describeWebhookTarget(client admissionregistrationv1.WebhookClientConfig) string {
+	if client.Service != nil {
+		target := fmt.Sprintf("%s/%s", client.Service.Namespace, client.Service.Name)
+		if client.Service.Port != nil {
+			target = fmt.Sprintf("%s:%d", target, *client.Service.Port)
+		}
+		if client.Service.Path != nil {
+			target += *client.Service.Path
+		}
+		return target
+	}
+	if client.URL != nil {
+		return *client.URL
+	}
+	return "<none>"
+}
+
+// describeWebhookRules formats the operations and resources a webhook matches
+func describeWebhookRules(rules []admissionregistrationv1.RuleWithOperations) string {
+	var parts []string
+	for _, rule := range rules {
+		var operations []string
+		for _, op := range rule.Operations {
+			operations = append(operations, string(op))
+		}
+		parts = append(parts, fmt.Sprintf("%s %s/%s/%s",
+			strings.Join(operations, ","),
+			strings.Join(rule.APIGroups, ","),
+			strings.Join(rule.APIVersions, ","),
+			strings.Join(rule.Resources, ",")))
+	}
+	if len(parts) == 0 {
+		return "<none>"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// getWebhooksSummary summarizes the RunAI-related admission webhooks
+func (c *Collector) getWebhooksSummary() (string, error) {
+	validating, mutating, err := c.getRunAIWebhookConfigurations()
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	output.WriteString("# Admission webhooks referencing RunAI\n\n")
+	output.WriteString("KIND\tCONFIGURATION\tWEBHOOK\tTARGET\tFAILURE POLICY\tRESOURCES\n")
+
+	failurePolicy := func(policy *admissionregistrationv1.FailurePolicyType) string {
+		if policy == nil {
+			return "Fail"
+		}
+		return string(*policy)
+	}
+
+	for _, config := range validating {
+		for _, webhook := range config.Webhooks {
+			output.WriteString(fmt.Sprintf("Validating\t%s\t%s\t%s\t%s\t%s\n",
+				config.Name, webhook.Name, describeWebhookTarget(webhook.ClientConfig),
+				failurePolicy(webhook.FailurePolicy), describeWebhookRules(webhook.Rules)))
+		}
+	}
+	for _, config := range mutating {
+		for _, webhook := range config.Webhooks {
+			output.WriteString(fmt.Sprintf("Mutating\t%s\t%s\t%s\t%s\t%s\n",
+				config.Name, webhook.Name, describeWebhookTarget(webhook.ClientConfig),
+				failurePolicy(webhook.FailurePolicy), describeWebhookRules(webhook.Rules)))
+		}
+	}
+
+	if len(validating) == 0 && len(mutating) == 0 {
+		output.WriteString("No RunAI webhook configurations found\n")
+	}
+
+	return output.String(), nil
+}
+
+// getWebhookConfigurationsYAML dumps the RunAI-related webhook configurations as YAML documents
+func (c *Collector) getWebhookConfigurationsYAML() (string, error) {
+	validating, mutating, err := c.getRunAIWebhookConfigurations()
+	if err != nil {
+		return "", err
+	}
+
+	var documents []string
+	for i := range validating {
+		config := &validating[i]
+		config.APIVersion = "admissionregistration.k8s.io/v1"
+		config.Kind = "ValidatingWebhookConfiguration"
+		output, err := c.objectToYAML(config)
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, output)
+	}
+	for i := range mutating {
+		config := &mutating[i]
+		config.APIVersion = "admissionregistration.k8s.io/v1"
+		config.Kind = "MutatingWebhookConfiguration"
+		output, err := c.objectToYAML(config)
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, output)
+	}
+
+	return strings.Join(documents, "---\n"), nil
+}
+
+// gvrCandidates maps common resource types to their GVR with fallback versions
+var gvrCandidates = map[string][]schema.GroupVersionResource{
+	"runaiconfig":           {{Group: "run.ai", Version: "v1", Resource: "runaiconfigs"}},
+	"configs.engine.run.ai": {{Group: "engine.run.ai", Version: "v1", Resource: "configs"}},
+	"rj":                    {{Group: "run.ai", Version: "v1", Resource: "runaijobs"}},
+	"pg":                    {{Group: "scheduling.run.ai", Version: "v1", Resource: "podgroups"}, {Group: "scheduling.k8s.io", Version: "v1", Resource: "podgroups"}},
+	"ksvc":                  {{Group: "serving.knative.dev", Version: "v1", Resource: "services"}},
+	// RunAI workload types with multiple version fallbacks
+	"trainingworkloads":             {{Group: "run.ai", Version: "v1", Resource: "trainingworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "trainingworkloads"}},
+	"interactiveworkloads":          {{Group: "run.ai", Version: "v1", Resource: "interactiveworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "interactiveworkloads"}},
+	"inferenceworkloads":            {{Group: "run.ai", Version: "v1", Resource: "inferenceworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "inferenceworkloads"}},
+	"distributedworkloads":          {{Group: "run.ai", Version: "v1", Resource: "distributedworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "distributedworkloads"}},
+	"distributedinferenceworkloads": {{Group: "run.ai", Version: "v1", Resource: "distributedinferenceworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "distributedinferenceworkloads"}},
+	"externalworkloads":             {{Group: "run.ai", Version: "v1", Resource: "externalworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "externalworkloads"}},
+	// RunAI scheduler resources
+	"projects":    {{Group: "run.ai", Version: "v2", Resource: "projects"}},
+	"queues":      {{Group: "scheduling.run.ai", Version: "v2", Resource: "queues"}, {Group: "scheduling.run.ai", Version: "v1", Resource: "queues"}},
+	"nodepools":   {{Group: "run.ai", Version: "v1alpha1", Resource: "nodepools"}},
+	"departments": {{Group: "scheduling.run.ai", Version: "v1", Resource: "departments"}},
 }
 
 // getResourceAsYAML gets any Kubernetes resource as YAML using dynamic client
 func (c *Collector) getResourceAsYAML(namespace, resource, name string) (string, error) {
-	// Map common resource types to their GVR with fallback versions
-	gvrCandidates := map[string][]schema.GroupVersionResource{
-		"runaiconfig":           {{Group: "run.ai", Version: "v1", Resource: "runaiconfigs"}},
-		"configs.engine.run.ai": {{Group: "engine.run.ai", Version: "v1", Resource: "configs"}},
-		"rj":                    {{Group: "run.ai", Version: "v1", Resource: "runaijobs"}},
-		"pg":                    {{Group: "scheduling.run.ai", Version: "v1", Resource: "podgroups"}, {Group: "scheduling.k8s.io", Version: "v1", Resource: "podgroups"}},
-		"ksvc":                  {{Group: "serving.knative.dev", Version: "v1", Resource: "services"}},
-		// RunAI workload types with multiple version fallbacks
-		"trainingworkloads":             {{Group: "run.ai", Version: "v1", Resource: "trainingworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "trainingworkloads"}},
-		"interactiveworkloads":          {{Group: "run.ai", Version: "v1", Resource: "interactiveworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "interactiveworkloads"}},
-		"inferenceworkloads":            {{Group: "run.ai", Version: "v1", Resource: "inferenceworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "inferenceworkloads"}},
-		"distributedworkloads":          {{Group: "run.ai", Version: "v1", Resource: "distributedworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "distributedworkloads"}},
-		"distributedinferenceworkloads": {{Group: "run.ai", Version: "v1", Resource: "distributedinferenceworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "distributedinferenceworkloads"}},
-		"externalworkloads":             {{Group: "run.ai", Version: "v1", Resource: "externalworkloads"}, {Group: "run.ai", Version: "v2alpha1", Resource: "externalworkloads"}},
-		// RunAI scheduler resources
-		"projects":    {{Group: "run.ai", Version: "v2", Resource: "projects"}},
-		"queues":      {{Group: "scheduling.run.ai", Version: "v2", Resource: "queues"}},
-		"nodepools":   {{Group: "run.ai", Version: "v1alpha1", Resource: "nodepools"}},
-		"departments": {{Group: "scheduling.run.ai", Version: "v1", Resource: "departments"}},
-	}
-
-	gvrList, exists := gvrCandidates[resource]
-	if !exists {
-		return "", fmt.Errorf("unknown resource type: %s", resource)
+	obj, err := c.getResource(namespace, resource, name)
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(obj)
+}
+
+// getResource gets any Kubernetes resource using dynamic client
+func (c *Collector) getResource(namespace, resource, name string) (*unstructured.Unstructured, error) {
+	gvrList, err := c.gvrsFor(resource)
+	if err != nil {
+		return nil, err
 	}
 
-	var obj runtime.Object
 	var lastErr error
 
 	// Try each GVR version until one works
 	for _, gvr := range gvrList {
-		var err error
-		if namespace != "" {
-			obj, err = c.dynamicClient.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
-		} else {
-			obj, err = c.dynamicClient.Resource(gvr).Get(context.TODO(), name, metav1.GetOptions{})
-		}
-
+		obj, err := c.resourceClient(gvr, namespace).Get(context.TODO(), name, metav1.GetOptions{})
 		if err == nil {
-			// Success - convert to YAML and return
-			return c.objectToYAML(obj)
+			return obj, nil
 		}
 		lastErr = err
 	}
 
 	// If we get here, all GVR versions failed
-	return "", lastErr
+	return nil, lastErr
 }
 
 // getPodsWithLabels gets pods with specific label selector
 func (c *Collector) getPodsWithLabels(namespace, labelSelector string) (*corev1.PodList, error) {
-	return c.clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+	pods, err := c.listPods(namespace, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.PodList{Items: pods}, nil
 }
 
 // getPodGroupsWithLabels gets podgroups with specific label selector using dynamic client
@@ -851,37 +2139,133 @@ func (c *Collector) getPodGroupsWithLabels(namespace, labelSelector string) (*un
 
 // objectToYAML converts a Kubernetes object to YAML string
 func (c *Collector) objectToYAML(obj runtime.Object) (string, error) {
-	yamlData, err := yaml.Marshal(obj)
+	var data interface{} = obj
+	if c.hasMetadataFilter() {
+		filtered, err := c.filterMetadata(obj)
+		if err != nil {
+			return "", err
+		}
+		data = filtered
+	}
+
+	yamlData, err := yaml.Marshal(data)
 	if err != nil {
 		return "", err
 	}
-	return string(yamlData), nil
+	return c.redact(string(yamlData)), nil
+}
+
+// redact replaces matches of the configured redaction rules
+func (c *Collector) redact(content string) string {
+	for _, pattern := range c.redactPatterns {
+		content = pattern.ReplaceAllString(content, redactedPlaceholder)
+	}
+	return content
+}
+
+// hasMetadataFilter reports whether any annotation or label filtering is configured
+func (c *Collector) hasMetadataFilter() bool {
+	return len(c.opts.StripAnnotations) > 0 || len(c.opts.KeepAnnotations) > 0 || len(c.opts.StripLabels) > 0
+}
+
+// filterMetadata converts an object to generic form and filters the annotations and
+// labels of every metadata block in it (including list items and pod templates)
+func (c *Collector) filterMetadata(obj runtime.Object) (interface{}, error) {
+	jsonData, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return nil, err
+	}
+
+	c.filterMetadataValue(data)
+	return data, nil
+}
+
+// filterMetadataValue walks a decoded JSON value and filters metadata blocks in place
+func (c *Collector) filterMetadataValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if metadata, ok := v["metadata"].(map[string]interface{}); ok {
+			if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+				for key := range annotations {
+					if (len(c.opts.KeepAnnotations) > 0 && !matchesAnyPattern(key, c.opts.KeepAnnotations)) || matchesAnyPattern(key, c.opts.StripAnnotations) {
+						delete(annotations, key)
+					}
+				}
+			}
+			if labels, ok := metadata["labels"].(map[string]interface{}); ok {
+				for key := range labels {
+					if matchesAnyPattern(key, c.opts.StripLabels) {
+						delete(labels, key)
+					}
+				}
+			}
+		}
+		for _, child := range v {
+			c.filterMetadataValue(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			c.filterMetadataValue(child)
+		}
+	}
+}
+
+// matchesAnyPattern reports whether key matches any of the '*' wildcard patterns
+func matchesAnyPattern(key string, patterns []string) bool {
+	_, matched := matchingPattern(key, patterns)
+	return matched
+}
+
+// matchingPattern returns the first '*' wildcard pattern that key matches
+func matchingPattern(key string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		if matched, _ := regexp.MatchString(expr, key); matched {
+			return pattern, true
+		}
+	}
+	return "", false
 }
 
 // getNamespaceByLabel gets namespace by label selector
 func (c *Collector) getNamespaceByLabel(labelSelector string) (string, error) {
-	namespaces, err := c.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+	namespaces, err := c.getNamespacesByLabel(labelSelector)
 	if err != nil {
 		return "", err
 	}
 
-	if len(namespaces.Items) == 0 {
+	if len(namespaces) == 0 {
 		return "", fmt.Errorf("no namespace found with label: %s", labelSelector)
 	}
 
-	return namespaces.Items[0].Name, nil
+	return namespaces[0], nil
 }
 
-// getCurrentContext gets the current kubectl context
-func (c *Collector) getCurrentContext() (string, error) {
-	// Load kubeconfig to get current context
-	config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+// getNamespacesByLabel returns the names of all namespaces matching a label selector, sorted
+func (c *Collector) getNamespacesByLabel(labelSelector string) ([]string, error) {
+	list, err := c.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var namespaces []string
+	for _, namespace := range list.Items {
+		namespaces = append(namespaces, namespace.Name)
 	}
-	return config.CurrentContext, nil
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// getCurrentContext gets the current kubectl context
+func (c *Collector) getCurrentContext() (string, error) {
+	return currentContextName(newKubeconfigLoader())
 }
 
 // testClusterConnection tests if we can connect to the cluster
@@ -952,10 +2336,14 @@ func (c *Collector) getHelmReleasesFromSecrets(namespace string) (string, error)
 		chart := "unknown"
 		appVersion := "unknown"
 
-		// Try to extract more info from secret data if available
+		// The chart is only recorded in the encoded release data
 		if secret.Type == "helm.sh/release.v1" && len(secret.Data) > 0 {
-			// For now, just use the labels we have
-			// Full parsing would require decoding the Helm release data
+			if release, err := decodeHelmRelease(&secret); err == nil {
+				chart = fmt.Sprintf("%s-%s", release.Chart.Metadata.Name, release.Chart.Metadata.Version)
+				if release.Chart.Metadata.AppVersion != "" {
+					appVersion = release.Chart.Metadata.AppVersion
+				}
+			}
 		}
 
 		output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\n",
@@ -972,110 +2360,195 @@ func (c *Collector) runCommand(name string, args ...string) (string, error) {
 	return string(output), err
 }
 
+// resolveWorkload maps a workload type alias to its canonical resource name and a project
+// to its namespace
+func (c *Collector) resolveWorkload(project, workloadType string) (string, string, error) {
+	canonicalType := c.getCanonicalWorkloadType(workloadType)
+	if canonicalType == "" {
+		return "", "", fmt.Errorf("invalid workload type: %s. Valid types: tw, iw, infw, dw, dinfw, ew", workloadType)
+	}
+
+	namespace, err := c.getNamespaceByLabel(fmt.Sprintf("runai/queue=%s", project))
+	if err != nil || strings.TrimSpace(namespace) == "" {
+		return "", "", fmt.Errorf("no namespace found for project: %s", project)
+	}
+	return canonicalType, strings.TrimSpace(namespace), nil
+}
+
 // CollectWorkloadInfo collects detailed information about a specific RunAI workload
 func (c *Collector) CollectWorkloadInfo(project, workloadType, name string) error {
-	fmt.Printf("🚀 Starting workload info collection for '%s' (%s) in project '%s'...\n", name, workloadType, project)
+	console.Printf("🚀 Starting workload info collection for '%s' (%s) in project '%s'...\n", name, workloadType, project)
 
 	// Check required tools
 	if err := c.checkRequiredTools(); err != nil {
 		return fmt.Errorf("required tools check failed: %w", err)
 	}
 
-	// Map type aliases to canonical resource names
-	canonicalType := c.getCanonicalWorkloadType(workloadType)
-	if canonicalType == "" {
-		return fmt.Errorf("invalid workload type: %s. Valid types: tw, iw, infw, dw, dinfw, ew", workloadType)
-	}
-
-	// Resolve namespace from project
-	fmt.Printf("🔍 Resolving namespace for project '%s'...\n", project)
-	namespace, err := c.getNamespaceByLabel(fmt.Sprintf("runai/queue=%s", project))
-	if err != nil || strings.TrimSpace(namespace) == "" {
-		return fmt.Errorf("no namespace found for project: %s", project)
+	console.Printf("🔍 Resolving namespace for project '%s'...\n", project)
+	canonicalType, namespace, err := c.resolveWorkload(project, workloadType)
+	if err != nil {
+		return err
 	}
-	namespace = strings.TrimSpace(namespace)
-	fmt.Printf("✅ Found namespace: %s\n", namespace)
+	console.Printf("✅ Found namespace: %s\n", namespace)
 
-	// Create timestamp and prepare file names
-	timestamp := time.Now().Format("2006_01_02-15_04")
+	// Prepare file names
+	timestamp := archiveTimestamp(c.opts, c.startTime, legacyWorkloadTimestampLayout)
 	typeSafe := strings.Replace(workloadType, "/", "_", -1)
-	archiveName := fmt.Sprintf("%s_%s_%s_%s.tar.gz", project, typeSafe, name, timestamp)
+	archiveName := fmt.Sprintf("%s%s_%s_%s_%s%s", c.opts.OutputPrefix, project, typeSafe, name, timestamp, c.archiveExtension())
 
 	var outputFiles []string
+	c.commands.take()
 
-	fmt.Println("\n📁 Starting collection process...")
+	console.Println("\n📁 Starting collection process...")
 
-	// Collect workload YAML
-	if file, err := c.getWorkloadYAML(namespace, name, canonicalType, typeSafe); err != nil {
-		if strings.Contains(err.Error(), "unknown resource type") {
-			fmt.Printf("❌ Failed to get workload YAML: %v (check if RunAI workload CRDs are installed)\n", err)
-		} else {
-			fmt.Printf("❌ Failed to get workload YAML: %v\n", err)
-		}
-	} else {
-		outputFiles = append(outputFiles, file)
-	}
+	// The pod list is fetched once for the steps deriving their files from the pod specs
+	workloadPods := sync.OnceValues(func() (*corev1.PodList, error) {
+		return c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", name))
+	})
 
-	// Collect RunAIJob YAML
-	if file, err := c.getRunAIJobYAML(namespace, name, typeSafe); err != nil {
-		fmt.Printf("❌ Failed to get RunAIJob YAML: %v\n", err)
-	} else {
-		outputFiles = append(outputFiles, file)
+	// The steps are independent, so they run in parallel; their files keep the step order
+	steps := []workloadStep{
+		{"workload YAML", func() ([]string, error) {
+			file, err := c.getWorkloadYAML(namespace, name, canonicalType, typeSafe)
+			if err != nil && strings.Contains(err.Error(), "unknown resource type") {
+				return nil, fmt.Errorf("%w (check if RunAI workload CRDs are installed)", err)
+			}
+			return oneFile(file, err)
+		}},
+		{"RunAIJob YAML", func() ([]string, error) {
+			return oneFile(c.getRunAIJobYAML(namespace, name, typeSafe))
+		}},
+		{"Pod YAML", func() ([]string, error) {
+			return oneFile(c.getPodYAML(workloadPods, name, typeSafe))
+		}},
+		{"PodGroup YAML", func() ([]string, error) {
+			return oneFile(c.getPodGroupYAML(namespace, name, typeSafe))
+		}},
+		{"Pod logs", func() ([]string, error) {
+			return c.getPodLogs(namespace, name, typeSafe)
+		}},
+		{"Pod events", func() ([]string, error) {
+			return oneFile(c.getPodEvents(namespace, name, typeSafe))
+		}},
+		{"Pod describe output", func() ([]string, error) {
+			return oneFile(c.getPodDescribe(namespace, name, typeSafe))
+		}},
+		// Explain why Pending workload pods do not fit on the nodes
+		{"scheduling analysis", func() ([]string, error) {
+			return oneFile(c.getSchedulingAnalysis(namespace, name, typeSafe))
+		}},
+		{"volume mounts", func() ([]string, error) {
+			return oneFile(c.getWorkloadMounts(workloadPods, namespace, name, typeSafe))
+		}},
+		// Nodes hosting the workload pods
+		{"Node information", func() ([]string, error) {
+			return c.getWorkloadNodes(namespace, name, typeSafe)
+		}},
 	}
-
-	// Collect Pod YAML
-	if file, err := c.getPodYAML(namespace, name, typeSafe); err != nil {
-		fmt.Printf("❌ Failed to get Pod YAML: %v\n", err)
-	} else {
-		outputFiles = append(outputFiles, file)
+	if canonicalType == "inferenceworkloads" {
+		steps = append(steps, workloadStep{"KSVC YAML", func() ([]string, error) {
+			return oneFile(c.getKSVCYAML(namespace, name, typeSafe))
+		}})
 	}
-
-	// Collect PodGroup YAML
-	if file, err := c.getPodGroupYAML(namespace, name, typeSafe); err != nil {
-		fmt.Printf("❌ Failed to get PodGroup YAML: %v\n", err)
-	} else {
-		outputFiles = append(outputFiles, file)
+	if isInferenceWorkload(canonicalType) {
+		steps = append(steps, workloadStep{"autoscalers", func() ([]string, error) {
+			return c.getWorkloadScaling(namespace, name, typeSafe)
+		}})
 	}
+	outputFiles = append(outputFiles, c.runWorkloadSteps(steps)...)
 
-	// Collect Pod logs
-	if files, err := c.getPodLogs(namespace, name, typeSafe); err != nil {
-		fmt.Printf("❌ Failed to get Pod logs: %v\n", err)
+	// Document the kubectl equivalents of the requests made for this workload
+	commandsFile := fmt.Sprintf("%s_%s_commands.txt", name, typeSafe)
+	if err := c.writeCommands(commandsFile); err != nil {
+		console.Printf("❌ Failed to write commands: %v\n", err)
 	} else {
-		outputFiles = append(outputFiles, files...)
-	}
-
-	// Collect KSVC for inference workloads
-	if canonicalType == "inferenceworkloads" {
-		if file, err := c.getKSVCYAML(namespace, name, typeSafe); err != nil {
-			fmt.Printf("❌ Failed to get KSVC YAML: %v\n", err)
-		} else {
-			outputFiles = append(outputFiles, file)
-		}
+		outputFiles = append(outputFiles, commandsFile)
 	}
 
 	// Create archive
-	fmt.Printf("\n📦 Creating archive: %s\n", archiveName)
+	console.Printf("\n📦 Creating archive: %s\n", archiveName)
 	if err := c.createWorkloadArchive(archiveName, outputFiles); err != nil {
+		os.Remove(archiveName)
 		return fmt.Errorf("failed to create archive: %w", err)
 	}
 
 	// Clean up individual files
-	fmt.Println("\n🧹 Cleaning up individual files...")
+	console.Println("\n🧹 Cleaning up individual files...")
 	for _, file := range outputFiles {
 		if err := os.Remove(file); err == nil {
-			fmt.Printf("  🗑️  Deleted: %s\n", file)
+			console.Printf("  🗑️  Deleted: %s\n", file)
 		}
 	}
 
-	fmt.Printf("\n✅ Workload info collection completed!\n")
-	fmt.Printf("📦 Archive created: %s\n", archiveName)
+	console.Printf("\n✅ Workload info collection completed!\n")
+	console.Printf("📦 Archive created: %s\n", archiveName)
 
 	return nil
 }
 
+// workloadStep is one independent part of the workload collection, returning the files it wrote
+type workloadStep struct {
+	name    string
+	collect func() ([]string, error)
+}
+
+// oneFile adapts a step collecting a single file
+func oneFile(file string, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+// runWorkloadSteps runs the steps in parallel, bounded by the concurrency option, and
+// returns the files of the successful steps in step order. Failures are reported as each
+// step finishes and summarized once all are done.
+func (c *Collector) runWorkloadSteps(steps []workloadStep) []string {
+	workers := c.opts.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([][]string, len(steps))
+	var failed []string
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, workers)
+	for i, step := range steps {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, step workloadStep) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			files, err := step.collect()
+			c.emit(progressEvent{Phase: "workload", Item: step.name}, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				console.Printf("❌ Failed to get %s: %v\n", step.name, err)
+				failed = append(failed, step.name)
+				return
+			}
+			results[i] = files
+		}(i, step)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		console.Printf("⚠️  %d of %d collection steps failed: %s\n", len(failed), len(steps), strings.Join(failed, ", "))
+	}
+
+	var outputFiles []string
+	for _, files := range results {
+		outputFiles = append(outputFiles, files...)
+	}
+	return outputFiles
+}
+
 // CollectSchedulerInfo collects RunAI scheduler information and resources
 func (c *Collector) CollectSchedulerInfo() error {
-	fmt.Println("🚀 Starting RunAI scheduler info collection...")
+	console.Println("🚀 Starting RunAI scheduler info collection...")
 
 	// Check required tools
 	if err := c.checkRequiredTools(); err != nil {
@@ -1086,14 +2559,13 @@ func (c *Collector) CollectSchedulerInfo() error {
 	if err := c.testClusterConnection(); err != nil {
 		return fmt.Errorf("cannot connect to Kubernetes cluster: %w", err)
 	}
-	fmt.Println("✅ Connected to Kubernetes cluster")
+	console.Println("✅ Connected to Kubernetes cluster")
 
-	// Create timestamp and archive name
-	timestamp := time.Now().Format("02-01-2006_15-04")
-	archiveName := fmt.Sprintf("scheduler_info_dump_%s", timestamp)
+	// Create archive name
+	archiveName := fmt.Sprintf("%sscheduler_info_dump_%s", c.opts.OutputPrefix, c.timestamp)
 	tempDir := archiveName
 
-	fmt.Printf("📁 Creating temp directory: %s\n", tempDir)
+	console.Printf("📁 Creating temp directory: %s\n", tempDir)
 	if err := os.MkdirAll(tempDir, 0755); err != nil {
 		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
@@ -1113,6 +2585,9 @@ func (c *Collector) CollectSchedulerInfo() error {
 		os.Chdir(originalDir)
 	}()
 
+	// commands.txt lists the requests made from here on
+	c.commands.take()
+
 	// Collect scheduler resources
 	resources := []struct {
 		resourceType string
@@ -1125,130 +2600,228 @@ func (c *Collector) CollectSchedulerInfo() error {
 	}
 
 	for _, resource := range resources {
-		if err := c.dumpSchedulerResource(resource.resourceType, resource.singular); err != nil {
-			fmt.Printf("⚠️  Warning: Failed to dump %s: %v\n", resource.resourceType, err)
+		if len(c.opts.ResourceTypes) > 0 && !containsString(c.opts.ResourceTypes, resource.resourceType) {
+			console.Printf("⏭️  Skipping %s (not selected by profile)\n", resource.resourceType)
+			continue
+		}
+		err := c.dumpSchedulerResource(resource.resourceType, resource.singular)
+		var unavailable *resourceUnavailableError
+		if errors.As(err, &unavailable) {
+			c.emit(progressEvent{Phase: "scheduler", Item: resource.resourceType, Status: progressSkipped, Error: err.Error()}, nil)
+			console.Printf("⏭️  Skipping %s (%v)\n", resource.resourceType, err)
+			continue
+		}
+		c.emit(progressEvent{Phase: "scheduler", Item: resource.resourceType}, err)
+		if err != nil {
+			console.Printf("⚠️  Warning: Failed to dump %s: %v\n", resource.resourceType, err)
 		} else {
 			// Validate that the list file has meaningful content
 			listFile := fmt.Sprintf("%s_list.txt", resource.resourceType)
 			if err := c.validateFileContent(listFile); err != nil {
-				fmt.Printf("⚠️  Warning: %v\n", err)
+				console.Printf("⚠️  Warning: %v\n", err)
 			}
 		}
 	}
 
+	// Collect pending pods and scheduling events explaining why workloads are not running
+	console.Println("📊 Collecting unschedulable pods and scheduling events...")
+	if output, err := c.getUnschedulableSummary(); err != nil {
+		console.Printf("⚠️  Warning: Failed to collect scheduling events: %v\n", err)
+	} else if err := os.WriteFile("unschedulable.txt", []byte(output), 0644); err != nil {
+		console.Printf("⚠️  Warning: Failed to write unschedulable.txt: %v\n", err)
+	}
+
+	// Which pods hold which GPUs on which nodes
+	console.Println("📊 Collecting GPU allocation...")
+	output, err := c.getGPUAllocation()
+	if err == nil {
+		err = os.WriteFile("gpu-allocation.txt", []byte(output), 0644)
+	}
+	c.emit(progressEvent{Phase: "scheduler", Item: "gpu-allocation"}, err)
+	if err != nil {
+		console.Printf("⚠️  Warning: Failed to collect GPU allocation: %v\n", err)
+	}
+
+	// The scheduler's actions, plugins and weights behind its decisions
+	console.Println("📊 Collecting scheduler config...")
+	manifests, plugins, err := c.getSchedulerConfig()
+	if err == nil {
+		err = os.WriteFile("scheduler-config.yaml", []byte(manifests), 0644)
+	}
+	if err == nil {
+		err = os.WriteFile("scheduler-plugins.txt", []byte(plugins), 0644)
+	}
+	c.emit(progressEvent{Phase: "scheduler", Item: "scheduler-config"}, err)
+	if err != nil {
+		console.Printf("⚠️  Warning: Failed to collect scheduler config: %v\n", err)
+	}
+
+	// The workloads competing for the resources above
+	if c.opts.WorkloadsOverview {
+		console.Println("📊 Collecting workloads overview...")
+		output, err := c.getWorkloadsOverview()
+		if err == nil {
+			err = os.WriteFile("workloads-overview.txt", []byte(output), 0644)
+		}
+		c.emit(progressEvent{Phase: "scheduler", Item: "workloads-overview"}, err)
+		if err != nil {
+			console.Printf("⚠️  Warning: Failed to collect workloads overview: %v\n", err)
+		}
+	}
+
+	// Resources requested with --extra-resource, from every namespace
+	c.collectExtraResources("", ".", io.Discard)
+
+	if err := c.writeCommands("commands.txt"); err != nil {
+		console.Printf("⚠️  Warning: Failed to write commands.txt: %v\n", err)
+	}
+
 	// Go back to original directory
 	if err := os.Chdir(originalDir); err != nil {
 		return fmt.Errorf("failed to change back to original directory: %w", err)
 	}
 
 	// Create archive
-	archiveFile := fmt.Sprintf("%s.tar.gz", archiveName)
-	fmt.Printf("\n📦 Creating archive: %s\n", archiveFile)
+	archiveFile := archiveName + c.archiveExtension()
+	console.Printf("\n📦 Creating archive: %s\n", archiveFile)
 
-	cmd := fmt.Sprintf("tar -czf %s %s", archiveFile, tempDir)
-	if _, err := c.runCommand("sh", "-c", cmd); err != nil {
-		return fmt.Errorf("failed to create archive: %w", err)
+	if _, err := c.writeArchive(tempDir, archiveFile, true); err != nil {
+		os.Remove(archiveFile)
+		return fmt.Errorf("failed to create archive, keeping %s: %w", tempDir, err)
 	}
 
 	// Clean up temp directory
 	if err := os.RemoveAll(tempDir); err != nil {
-		fmt.Printf("⚠️  Warning: Failed to clean up temp directory: %v\n", err)
-	}
-
-	fmt.Printf("\n✅ Scheduler info collection completed!\n")
-	fmt.Printf("📦 Archive created: %s\n", archiveFile)
-	fmt.Println("\n📋 Archive contains:")
-	fmt.Println("  - projects_list.txt (projects list)")
-	fmt.Println("  - project_*.yaml (individual projects)")
-	fmt.Println("  - queues_list.txt (queues list)")
-	fmt.Println("  - queue_*.yaml (individual queues)")
-	fmt.Println("  - nodepools_list.txt (nodepools list)")
-	fmt.Println("  - nodepool_*.yaml (individual nodepools)")
-	fmt.Println("  - departments_list.txt (departments list)")
-	fmt.Println("  - department_*.yaml (individual departments)")
+		console.Printf("⚠️  Warning: Failed to clean up temp directory: %v\n", err)
+	}
+
+	console.Printf("\n✅ Scheduler info collection completed!\n")
+	console.Printf("📦 Archive created: %s\n", archiveFile)
+	console.Println("\n📋 Archive contains:")
+	console.Println("  - projects_list.txt (projects list)")
+	console.Println("  - project_*.yaml (individual projects)")
+	console.Println("  - queues_list.txt (queues list)")
+	console.Println("  - queue_*.yaml (individual queues)")
+	console.Println("  - nodepools_list.txt (nodepools list)")
+	console.Println("  - nodepool_*.yaml (individual nodepools)")
+	console.Println("  - departments_list.txt (departments list)")
+	console.Println("  - department_*.yaml (individual departments)")
+	console.Println("  - unschedulable.txt (pending pods and scheduling events)")
+	console.Println("  - commands.txt (kubectl equivalents of the requests made)")
 
 	return nil
 }
 
 // RunTests performs environment verification and connectivity tests
 func (c *Collector) RunTests() error {
-	fmt.Println("🧪 Running environment tests for RunAI log collection...")
-	fmt.Println()
+	console.Println("🧪 Running environment tests for RunAI log collection...")
+	console.Println()
 
 	// Test 1: Check required tools
-	fmt.Println("🔧 Testing required tools...")
+	console.Println("🔧 Testing required tools...")
 	if err := c.testRequiredTools(); err != nil {
 		return err
 	}
 
 	// Test 2: Test cluster connectivity
-	fmt.Println("\n🌐 Testing cluster connectivity...")
+	console.Println("\n🌐 Testing cluster connectivity...")
 	if err := c.testClusterConnectivity(); err != nil {
-		return err
+		return exitError(ExitConnectivity, err)
 	}
 
 	// Test 3: Check RunAI namespaces
-	fmt.Println("\n📋 Checking RunAI namespaces...")
+	console.Println("\n📋 Checking RunAI namespaces...")
 	if err := c.testRunAINamespaces(); err != nil {
 		return err
 	}
 
 	// Test 4: Extract and display RunAI information
-	fmt.Println("\n📊 Retrieving RunAI cluster information...")
+	console.Println("\n📊 Retrieving RunAI cluster information...")
 	if err := c.displayRunAIInfo(); err != nil {
-		fmt.Printf("⚠️  Warning: Could not retrieve RunAI information: %v\n", err)
+		console.Printf("⚠️  Warning: Could not retrieve RunAI information: %v\n", err)
+	}
+	_, configErr := c.getRunAIConfig()
+
+	// Test 5: The control plane the cluster components report to
+	console.Println("\n🛰️  Testing control plane reachability...")
+	controlPlaneErr := c.testControlPlaneReachability()
+
+	// Test 6: Summarize common problems with remediation hints
+	console.Println("\n🩺 Diagnosis...")
+	diagnosisErr := c.printDiagnosis(c.diagnose())
+
+	// Every problem is reported, with the lowest of their exit codes
+	var problems []string
+	var codes []int
+	if diagnosisErr != nil {
+		problems = append(problems, diagnosisErr.Error())
+	}
+	if configErr != nil {
+		problems = append(problems, fmt.Sprintf("runaiconfig unreadable: %v", configErr))
+		codes = append(codes, ExitConfigUnreadable)
+	}
+	if controlPlaneErr != nil {
+		problems = append(problems, controlPlaneErr.Error())
+		codes = append(codes, ExitCode(controlPlaneErr))
+	}
+	if len(problems) > 0 {
+		err := errors.New(strings.Join(problems, "; "))
+		if code := lowestExitCode(codes...); code != ExitOK {
+			return exitError(code, err)
+		}
+		return err
 	}
 
-	fmt.Println("\n🎉 All tests passed! Environment is ready for log collection.")
-	fmt.Println("\nRun 'nmcrun logs' to start collecting logs.")
+	console.Println("\n🎉 All tests passed! Environment is ready for log collection.")
+	console.Println("\nRun 'nmcrun logs' to start collecting logs.")
 
 	return nil
 }
 
 // testRequiredTools checks system requirements (no external tools needed)
 func (c *Collector) testRequiredTools() error {
-	fmt.Printf("  🔧 Checking system requirements... ")
+	console.Printf("  🔧 Checking system requirements... ")
 
 	// No external tools required! Everything uses native Kubernetes Go client libraries
-	fmt.Printf("✅ SATISFIED\n")
-	fmt.Printf("    Using native Kubernetes Go client libraries (no external tools required)\n")
+	console.Printf("✅ SATISFIED\n")
+	console.Printf("    Using native Kubernetes Go client libraries (no external tools required)\n")
 
 	return nil
 }
 
 // testClusterConnectivity tests if kubectl can connect to the cluster
 func (c *Collector) testClusterConnectivity() error {
-	fmt.Printf("  🔗 Testing Kubernetes cluster connection... ")
+	console.Printf("  🔗 Testing Kubernetes cluster connection... ")
 
 	// Try to get nodes to test connection
 	err := c.testClusterConnection()
 	if err != nil {
-		fmt.Printf("❌ FAILED\n")
+		console.Printf("❌ FAILED\n")
 		return fmt.Errorf("cannot connect to cluster: %v", err)
 	}
 
-	fmt.Printf("✅ CONNECTED\n")
+	console.Printf("✅ CONNECTED\n")
 
 	// Try to get nodes to verify permissions
-	fmt.Printf("  👥 Testing cluster permissions... ")
+	console.Printf("  👥 Testing cluster permissions... ")
 	_, err = c.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{Limit: 1})
 	if err != nil {
-		fmt.Printf("⚠️  LIMITED\n")
-		fmt.Printf("    Warning: Cannot list nodes (may have limited permissions): %v\n", err)
+		console.Printf("⚠️  LIMITED\n")
+		console.Printf("    Warning: Cannot list nodes (may have limited permissions): %v\n", err)
 	} else {
-		fmt.Printf("✅ SUFFICIENT\n")
+		console.Printf("✅ SUFFICIENT\n")
 	}
 
 	// Show current context
 	context, err := c.getCurrentContext()
 	if err == nil {
-		fmt.Printf("  📍 Current context: %s\n", strings.TrimSpace(context))
+		console.Printf("  📍 Current context: %s\n", strings.TrimSpace(context))
 	}
 
 	// Show cluster version info
 	version, err := c.clientset.Discovery().ServerVersion()
 	if err == nil {
-		fmt.Printf("  🎯 Kubernetes server version: %s\n", version.String())
+		console.Printf("  🎯 Kubernetes server version: %s\n", version.String())
 	}
 
 	return nil
@@ -1258,40 +2831,54 @@ func (c *Collector) testClusterConnectivity() error {
 func (c *Collector) testRunAINamespaces() error {
 	namespaces := []string{"runai", "runai-backend"}
 	foundNamespaces := []string{}
+	forbidden := false
 
 	for _, namespace := range namespaces {
-		fmt.Printf("  📂 Checking namespace '%s'... ", namespace)
+		console.Printf("  📂 Checking namespace '%s'... ", namespace)
 
-		if c.namespaceExists(namespace) {
-			fmt.Printf("✅ EXISTS\n")
+		exists, err := c.namespaceExists(namespace)
+		if err != nil {
+			console.Printf("⚠️  ERROR: %v\n", err)
+			forbidden = forbidden || apierrors.IsForbidden(err)
+			continue
+		}
+		if exists {
+			console.Printf("✅ EXISTS\n")
 			foundNamespaces = append(foundNamespaces, namespace)
 
 			// Count pods in namespace
 			pods, err := c.getPods(namespace)
 			if err == nil {
-				fmt.Printf("    📦 %d pods found\n", len(pods))
+				console.Printf("    📦 %d pods found\n", len(pods))
 			}
 		} else {
-			fmt.Printf("❌ NOT FOUND\n")
+			console.Printf("❌ NOT FOUND\n")
 		}
 	}
 
+	if len(foundNamespaces) == 0 && forbidden {
+		return exitError(ExitPartial, fmt.Errorf("cannot check the RunAI namespaces: access is forbidden"))
+	}
 	if len(foundNamespaces) == 0 {
-		return fmt.Errorf("no RunAI namespaces found. Expected 'runai' and/or 'runai-backend'")
+		return exitError(ExitNamespacesMissing, fmt.Errorf("no RunAI namespaces found. Expected 'runai' and/or 'runai-backend'"))
 	}
 
-	fmt.Printf("  ✅ Found %d RunAI namespace(s): %s\n", len(foundNamespaces), strings.Join(foundNamespaces, ", "))
+	console.Printf("  ✅ Found %d RunAI namespace(s): %s\n", len(foundNamespaces), strings.Join(foundNamespaces, ", "))
 	return nil
 }
 
 // displayRunAIInfo extracts and displays RunAI cluster information
 func (c *Collector) displayRunAIInfo() error {
 	// Check if runai namespace exists
-	if !c.namespaceExists("runai") {
+	exists, err := c.namespaceExists("runai")
+	if err != nil {
+		return fmt.Errorf("failed to check runai namespace: %w", err)
+	}
+	if !exists {
 		return fmt.Errorf("runai namespace not found")
 	}
 
-	fmt.Printf("  🔍 Extracting RunAI configuration...\n")
+	console.Printf("  🔍 Extracting RunAI configuration...\n")
 
 	// Extract cluster and control plane URLs
 	clusterURL, cpURL, err := c.extractClusterInfo()
@@ -1299,36 +2886,35 @@ func (c *Collector) displayRunAIInfo() error {
 		return fmt.Errorf("failed to extract cluster info: %w", err)
 	}
 
-	fmt.Printf("  🌐 Cluster URL: %s\n", clusterURL)
-	fmt.Printf("  🎛️  Control Plane URL: %s\n", cpURL)
+	console.Printf("  🌐 Cluster URL: %s\n", clusterURL)
+	console.Printf("  🎛️  Control Plane URL: %s\n", cpURL)
 
 	// Try to get RunAI version
-	fmt.Printf("  📊 Checking RunAI components...\n")
+	console.Printf("  📊 Checking RunAI components...\n")
 
 	// Check if runaiconfig exists
-	gvr := schema.GroupVersionResource{Group: "run.ai", Version: "v1", Resource: "runaiconfigs"}
-	runaiConfigObj, err := c.dynamicClient.Resource(gvr).Namespace("runai").Get(context.TODO(), "runai", metav1.GetOptions{})
+	runaiConfigObj, err := c.getRunAIConfig()
 	if err == nil {
-		fmt.Printf("    ✅ RunAI configuration found\n")
+		console.Printf("    ✅ RunAI configuration found\n")
 
 		// Try to get RunAI version from config
 		if version, found, _ := unstructured.NestedString(runaiConfigObj.Object, "spec", "global", "image", "tag"); found && strings.TrimSpace(version) != "" {
-			fmt.Printf("    📋 RunAI version: %s\n", strings.TrimSpace(version))
+			console.Printf("    📋 RunAI version: %s\n", strings.TrimSpace(version))
 		}
 	} else {
-		fmt.Printf("    ⚠️  RunAI configuration not found\n")
+		console.Printf("    ⚠️  RunAI configuration not found\n")
 	}
 
 	// Get RunAI cluster version from configmap
 	cm, err := c.clientset.CoreV1().ConfigMaps("runai").Get(context.TODO(), "runai-public", metav1.GetOptions{})
 	if err == nil {
 		if clusterVersion, exists := cm.Data["cluster-version"]; exists && strings.TrimSpace(clusterVersion) != "" {
-			fmt.Printf("    📊 RunAI cluster version: %s\n", strings.TrimSpace(clusterVersion))
+			console.Printf("    📊 RunAI cluster version: %s\n", strings.TrimSpace(clusterVersion))
 		} else {
-			fmt.Printf("    ⚠️  RunAI cluster version not found in configmap\n")
+			console.Printf("    ⚠️  RunAI cluster version not found in configmap\n")
 		}
 	} else {
-		fmt.Printf("    ⚠️  RunAI cluster version configmap not found\n")
+		console.Printf("    ⚠️  RunAI cluster version configmap not found\n")
 	}
 
 	// Check Helm releases (from Kubernetes secrets)
@@ -1336,20 +2922,20 @@ func (c *Collector) displayRunAIInfo() error {
 		LabelSelector: "owner=helm",
 	})
 	if err == nil && len(secrets.Items) > 0 {
-		fmt.Printf("    ✅ %d Helm release(s) found in runai namespace\n", len(secrets.Items))
+		console.Printf("    ✅ %d Helm release(s) found in runai namespace\n", len(secrets.Items))
 		for _, secret := range secrets.Items {
 			if name := secret.Labels["name"]; name != "" {
 				status := secret.Labels["status"]
 				if status == "" {
 					status = "unknown"
 				}
-				fmt.Printf("      - %s (status: %s)\n", name, status)
+				console.Printf("      - %s (status: %s)\n", name, status)
 			}
 		}
 	} else if err != nil {
-		fmt.Printf("    ⚠️  Could not check Helm releases: %v\n", err)
+		console.Printf("    ⚠️  Could not check Helm releases: %v\n", err)
 	} else {
-		fmt.Printf("    ⚠️  No Helm releases found in runai namespace\n")
+		console.Printf("    ⚠️  No Helm releases found in runai namespace\n")
 	}
 
 	return nil
@@ -1380,7 +2966,7 @@ func (c *Collector) getCanonicalWorkloadType(workloadType string) string {
 // getWorkloadYAML retrieves workload YAML
 func (c *Collector) getWorkloadYAML(namespace, workload, canonicalType, typeSafe string) (string, error) {
 	filename := fmt.Sprintf("%s_%s_workload.yaml", workload, typeSafe)
-	fmt.Printf("  📄 Getting %s YAML...\n", canonicalType)
+	console.Printf("  📄 Getting %s YAML...\n", canonicalType)
 
 	output, err := c.getResourceAsYAML(namespace, canonicalType, workload)
 	if err != nil {
@@ -1391,14 +2977,14 @@ func (c *Collector) getWorkloadYAML(namespace, workload, canonicalType, typeSafe
 		return "", err
 	}
 
-	fmt.Printf("    ✅ Workload YAML retrieved\n")
+	console.Printf("    ✅ Workload YAML retrieved\n")
 	return filename, nil
 }
 
 // getRunAIJobYAML retrieves RunAIJob YAML
 func (c *Collector) getRunAIJobYAML(namespace, workload, typeSafe string) (string, error) {
 	filename := fmt.Sprintf("%s_%s_runaijob.yaml", workload, typeSafe)
-	fmt.Printf("  📄 Getting RunAIJob YAML...\n")
+	console.Printf("  📄 Getting RunAIJob YAML...\n")
 
 	output, err := c.getResourceAsYAML(namespace, "rj", workload)
 	if err != nil {
@@ -1409,16 +2995,16 @@ func (c *Collector) getRunAIJobYAML(namespace, workload, typeSafe string) (strin
 		return "", err
 	}
 
-	fmt.Printf("    ✅ RunAIJob YAML retrieved\n")
+	console.Printf("    ✅ RunAIJob YAML retrieved\n")
 	return filename, nil
 }
 
 // getPodYAML retrieves pod YAML
-func (c *Collector) getPodYAML(namespace, workload, typeSafe string) (string, error) {
+func (c *Collector) getPodYAML(workloadPods func() (*corev1.PodList, error), workload, typeSafe string) (string, error) {
 	filename := fmt.Sprintf("%s_%s_pod.yaml", workload, typeSafe)
-	fmt.Printf("  📄 Getting Pod YAML...\n")
+	console.Printf("  📄 Getting Pod YAML...\n")
 
-	pods, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
+	pods, err := workloadPods()
 	if err != nil {
 		return "", err
 	}
@@ -1431,14 +3017,14 @@ func (c *Collector) getPodYAML(namespace, workload, typeSafe string) (string, er
 		return "", err
 	}
 
-	fmt.Printf("    ✅ Pod YAML retrieved\n")
+	console.Printf("    ✅ Pod YAML retrieved\n")
 	return filename, nil
 }
 
 // getPodGroupYAML retrieves podgroup YAML
 func (c *Collector) getPodGroupYAML(namespace, workload, typeSafe string) (string, error) {
 	filename := fmt.Sprintf("%s_%s_podgroup.yaml", workload, typeSafe)
-	fmt.Printf("  📄 Getting PodGroup YAML...\n")
+	console.Printf("  📄 Getting PodGroup YAML...\n")
 
 	// PodGroups in RunAI have generated names, so we need to find them by labels
 	podGroups, err := c.getPodGroupsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
@@ -1460,13 +3046,13 @@ func (c *Collector) getPodGroupYAML(namespace, workload, typeSafe string) (strin
 		return "", err
 	}
 
-	fmt.Printf("    ✅ PodGroup YAML retrieved\n")
+	console.Printf("    ✅ PodGroup YAML retrieved\n")
 	return filename, nil
 }
 
 // getPodLogs retrieves pod logs
 func (c *Collector) getPodLogs(namespace, workload, typeSafe string) ([]string, error) {
-	fmt.Printf("  📄 Getting Pod Logs...\n")
+	console.Printf("  📄 Getting Pod Logs...\n")
 
 	// Get all pods for this workload
 	podList, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
@@ -1479,7 +3065,7 @@ func (c *Collector) getPodLogs(namespace, workload, typeSafe string) ([]string,
 		pods = append(pods, pod.Name)
 	}
 	if len(pods) == 0 {
-		fmt.Printf("    ⚠️  No pods found for workload: %s\n", workload)
+		console.Printf("    ⚠️  No pods found for workload: %s\n", workload)
 		return []string{}, nil
 	}
 
@@ -1487,7 +3073,7 @@ func (c *Collector) getPodLogs(namespace, workload, typeSafe string) ([]string,
 
 	// Iterate through each pod
 	for _, pod := range pods {
-		fmt.Printf("    🐳 Processing pod: %s\n", pod)
+		console.Printf("    🐳 Processing pod: %s\n", pod)
 
 		// Get all containers for this pod
 		containers, initContainers, err := c.getPodContainers(namespace, pod)
@@ -1496,38 +3082,47 @@ func (c *Collector) getPodLogs(namespace, workload, typeSafe string) ([]string,
 		}
 
 		// Combine init and regular containers
-		allContainers := append(initContainers, containers...)
+		allContainers, skipped := c.filterContainers(append(initContainers, containers...))
+		if len(skipped) > 0 {
+			console.Printf("      ⏭️  Skipping excluded containers: %s\n", strings.Join(skipped, ", "))
+		}
 
 		// Iterate through each container
 		for _, container := range allContainers {
-			logFile := fmt.Sprintf("%s_%s_pod_logs_%s.log", workload, typeSafe, container)
-			fmt.Printf("      📝 Getting logs for container: %s\n", container)
+			logFile := workloadLogFile(workload, pod, container)
+			console.Printf("      📝 Getting logs for container: %s\n", container)
 
 			output, err := c.getPodLogsForContainer(namespace, pod, container)
 			if err == nil {
 				if err := os.WriteFile(logFile, []byte(output), 0644); err == nil {
-					fmt.Printf("        ✅ Container logs retrieved: %s\n", container)
+					console.Printf("        ✅ Container logs retrieved: %s\n", container)
 					outputFiles = append(outputFiles, logFile)
 				}
 			} else {
-				fmt.Printf("        ❌ Failed to retrieve logs for container: %s\n", container)
+				console.Printf("        ❌ Failed to retrieve logs for container: %s\n", container)
 			}
 		}
 	}
 
 	if len(outputFiles) > 0 {
-		fmt.Printf("    ✅ Pod logs retrieved for %d containers\n", len(outputFiles))
+		console.Printf("    ✅ Pod logs retrieved for %d containers\n", len(outputFiles))
 	} else {
-		fmt.Printf("    ❌ No container logs were successfully retrieved\n")
+		console.Printf("    ❌ No container logs were successfully retrieved\n")
 	}
 
 	return outputFiles, nil
 }
 
+// workloadLogFile names the log file of a workload container. It includes the pod name so
+// replicas sharing a container name don't overwrite each other.
+func workloadLogFile(workload, pod, container string) string {
+	return fmt.Sprintf("%s_%s_%s.log", workload, pod, container)
+}
+
 // getKSVCYAML retrieves KSVC YAML for inference workloads
 func (c *Collector) getKSVCYAML(namespace, workload, typeSafe string) (string, error) {
 	filename := fmt.Sprintf("%s_%s_ksvc.yaml", workload, typeSafe)
-	fmt.Printf("  📄 Getting KSVC YAML...\n")
+	console.Printf("  📄 Getting KSVC YAML...\n")
 
 	output, err := c.getResourceAsYAML(namespace, "ksvc", workload)
 	if err != nil {
@@ -1538,7 +3133,7 @@ func (c *Collector) getKSVCYAML(namespace, workload, typeSafe string) (string, e
 		return "", err
 	}
 
-	fmt.Printf("    ✅ KSVC YAML retrieved\n")
+	console.Printf("    ✅ KSVC YAML retrieved\n")
 	return filename, nil
 }
 
@@ -1548,71 +3143,54 @@ func (c *Collector) createWorkloadArchive(archiveName string, files []string) er
 		return fmt.Errorf("no files to archive")
 	}
 
-	// Create tar.gz archive
 	archiveFile, err := os.Create(archiveName)
 	if err != nil {
 		return err
 	}
 	defer archiveFile.Close()
 
-	gzipWriter := gzip.NewWriter(archiveFile)
-	defer gzipWriter.Close()
-
-	tarWriter := tar.NewWriter(gzipWriter)
-	defer tarWriter.Close()
+	archiveHash := sha256.New()
+	writer, err := c.newArchive(io.MultiWriter(archiveFile, archiveHash), true)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
 
+	// Workload files are archived flat, without the directory they were written to
+	buffer := make([]byte, archiveBufferSize)
+	sums := &archiveChecksums{}
 	for _, file := range files {
-		if err := c.addFileToTar(tarWriter, file); err != nil {
+		fi, err := os.Stat(file)
+		var sum []byte
+		if err == nil {
+			sum, err = c.addArchiveFile(writer, file, filepath.Base(file), fi, buffer)
+		}
+		if err != nil {
 			return fmt.Errorf("failed to add %s to archive: %w", file, err)
 		}
+		sums.add(filepath.Base(file), sum)
 	}
-
-	return nil
-}
-
-// addFileToTar adds a file to tar archive
-func (c *Collector) addFileToTar(tarWriter *tar.Writer, filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	info, err := file.Stat()
-	if err != nil {
+	if err := sums.write(writer, checksumsFileName); err != nil {
 		return err
 	}
 
-	header, err := tar.FileInfoHeader(info, info.Name())
-	if err != nil {
+	if err := writer.Close(); err != nil {
 		return err
 	}
-
-	header.Name = filename
-
-	if err := tarWriter.WriteHeader(header); err != nil {
+	if err := archiveFile.Close(); err != nil {
 		return err
 	}
-
-	_, err = io.Copy(tarWriter, file)
-	return err
+	return writeChecksumFile(archiveName, archiveHash.Sum(nil))
 }
 
 // dumpSchedulerResource dumps a scheduler resource type using native client-go
 func (c *Collector) dumpSchedulerResource(resourceType, singular string) error {
-	fmt.Printf("📊 Dumping %s...\n", resourceType)
-
-	// Map resource types to their actual GVR based on RunAI API definitions
-	gvrCandidates := map[string][]schema.GroupVersionResource{
-		"projects":    {{Group: "run.ai", Version: "v2", Resource: "projects"}},
-		"queues":      {{Group: "scheduling.run.ai", Version: "v2", Resource: "queues"}},
-		"nodepools":   {{Group: "run.ai", Version: "v1alpha1", Resource: "nodepools"}},
-		"departments": {{Group: "scheduling.run.ai", Version: "v1", Resource: "departments"}},
-	}
+	console.Printf("📊 Dumping %s...\n", resourceType)
 
-	gvrList, exists := gvrCandidates[resourceType]
-	if !exists {
-		return fmt.Errorf("unknown scheduler resource type: %s", resourceType)
+	// Resolve the GVRs that can exist in the cluster's RunAI version
+	gvrList, err := c.gvrsFor(resourceType)
+	if err != nil {
+		return err
 	}
 
 	// Get resource list using dynamic client with fallback versions
@@ -1637,7 +3215,7 @@ func (c *Collector) dumpSchedulerResource(resourceType, singular string) error {
 		if err := os.WriteFile(listFile, []byte(errorOutput), 0644); err != nil {
 			return fmt.Errorf("failed to write %s error file: %w", resourceType, err)
 		}
-		fmt.Printf("⚠️  %s list saved with error info to %s\n", resourceType, listFile)
+		console.Printf("⚠️  %s list saved with error info to %s\n", resourceType, listFile)
 		return nil
 	}
 
@@ -1654,7 +3232,7 @@ func (c *Collector) dumpSchedulerResource(resourceType, singular string) error {
 
 	output.WriteString("NAME\tCREATED\tAGE\n")
 
-	resourceNames := []string{}
+	var resourceItems []schedulerResourceItem
 	for _, item := range resourceList.Items {
 		name := item.GetName()
 
@@ -1664,7 +3242,7 @@ func (c *Collector) dumpSchedulerResource(resourceType, singular string) error {
 			// Could add queue-specific metadata here if needed
 		}
 
-		resourceNames = append(resourceNames, name)
+		resourceItems = append(resourceItems, schedulerResourceItem{namespace: item.GetNamespace(), name: name})
 
 		creationTime := item.GetCreationTimestamp()
 		age := time.Since(creationTime.Time).Truncate(time.Second)
@@ -1679,50 +3257,89 @@ func (c *Collector) dumpSchedulerResource(resourceType, singular string) error {
 		return fmt.Errorf("failed to write %s list: %w", resourceType, err)
 	}
 
-	fmt.Printf("✅ %s list saved to %s (%d resources found)\n", resourceType, listFile, len(resourceList.Items))
+	console.Printf("✅ %s list saved to %s (%d resources found)\n", resourceType, listFile, len(resourceList.Items))
 
-	// Extract individual manifests
-	if len(resourceNames) > 0 {
-		fmt.Printf("📄 Extracting individual %s manifests...\n", resourceType)
+	// Extract individual manifests in parallel; the list file above keeps the stable ordering
+	if len(resourceItems) > 0 {
+		console.Printf("📄 Extracting individual %s manifests...\n", resourceType)
+
+		workers := c.opts.Concurrency
+		if workers < 1 {
+			workers = 1
+		}
 
-		for _, resourceName := range resourceNames {
-			manifestFile := fmt.Sprintf("%s_%s.yaml", singular, resourceName)
+		var wg sync.WaitGroup
+		var printMu sync.Mutex
+		sem := make(chan struct{}, workers)
+		for _, item := range resourceItems {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(item schedulerResourceItem) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				c.extractSchedulerManifest(gvrList, singular, item, &printMu)
+			}(item)
+		}
+		wg.Wait()
+	} else {
+		console.Printf("📄 No %s found to extract\n", resourceType)
+	}
 
-			// Get individual resource with fallback versions
-			var resource *unstructured.Unstructured
-			var resourceErr error
+	return nil
+}
 
-			for _, gvr := range gvrList {
-				resource, resourceErr = c.dynamicClient.Resource(gvr).Get(context.TODO(), resourceName, metav1.GetOptions{})
-				if resourceErr == nil {
-					break // Success
-				}
-			}
+// schedulerResourceItem identifies a listed scheduler resource; namespace is empty for
+// cluster-scoped kinds
+type schedulerResourceItem struct {
+	namespace string
+	name      string
+}
 
-			if resource == nil {
-				fmt.Printf("  ⚠️  Failed to get %s %s: %v\n", singular, resourceName, resourceErr)
-				continue
-			}
+// extractSchedulerManifest writes the YAML manifest of a single scheduler resource.
+// printMu serializes progress output when called from parallel workers.
+func (c *Collector) extractSchedulerManifest(gvrList []schema.GroupVersionResource, singular string, item schedulerResourceItem, printMu *sync.Mutex) {
+	logf := func(format string, args ...interface{}) {
+		printMu.Lock()
+		defer printMu.Unlock()
+		console.Printf(format, args...)
+	}
 
-			// Convert to YAML
-			manifestOutput, err := c.objectToYAML(resource)
-			if err != nil {
-				fmt.Printf("  ⚠️  Failed to convert %s %s to YAML: %v\n", singular, resourceName, err)
-				continue
-			}
+	resourceName := item.name
+	manifestFile := fmt.Sprintf("%s_%s.yaml", singular, resourceName)
+	if item.namespace != "" {
+		// Namespaced kinds can repeat a name across namespaces
+		manifestFile = fmt.Sprintf("%s_%s_%s.yaml", singular, item.namespace, resourceName)
+	}
 
-			if err := os.WriteFile(manifestFile, []byte(manifestOutput), 0644); err != nil {
-				fmt.Printf("  ⚠️  Failed to write %s %s: %v\n", singular, resourceName, err)
-				continue
-			}
+	// Get individual resource with fallback versions
+	var resource *unstructured.Unstructured
+	var resourceErr error
 
-			fmt.Printf("  ✅ Extracted %s: %s\n", singular, resourceName)
+	for _, gvr := range gvrList {
+		resource, resourceErr = c.resourceClient(gvr, item.namespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+		if resourceErr == nil {
+			break // Success
 		}
-	} else {
-		fmt.Printf("📄 No %s found to extract\n", resourceType)
 	}
 
-	return nil
+	if resource == nil {
+		logf("  ⚠️  Failed to get %s %s: %v\n", singular, resourceName, resourceErr)
+		return
+	}
+
+	// Convert to YAML
+	manifestOutput, err := c.objectToYAML(resource)
+	if err != nil {
+		logf("  ⚠️  Failed to convert %s %s to YAML: %v\n", singular, resourceName, err)
+		return
+	}
+
+	if err := os.WriteFile(manifestFile, []byte(manifestOutput), 0644); err != nil {
+		logf("  ⚠️  Failed to write %s %s: %v\n", singular, resourceName, err)
+		return
+	}
+
+	logf("  ✅ Extracted %s: %s\n", singular, resourceName)
 }
 
 // validateFileContent checks if a file has meaningful content (not just comments or empty)
@@ -1750,3 +3367,13 @@ func (c *Collector) validateFileContent(filename string) error {
 
 	return nil
 }
+
+// containsString reports whether value is in list
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
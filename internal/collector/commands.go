@@ -0,0 +1,168 @@
+package collector
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// commandRecorder is an http.RoundTripper wrapper recording the kubectl equivalent of
+// every API read made through the clients, so archives document how to reproduce them.
+// Repeated requests (e.g. list pages) are recorded once, in the order first made.
+type commandRecorder struct {
+	mu       sync.Mutex
+	commands []string
+	seen     map[string]bool
+}
+
+// wrap returns a transport recording requests before passing them to next
+func (r *commandRecorder) wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if command := kubectlEquivalent(req.Method, req.URL); command != "" {
+			r.record(command)
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+func (r *commandRecorder) record(command string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen == nil {
+		r.seen = make(map[string]bool)
+	}
+	if r.seen[command] {
+		return
+	}
+	r.seen[command] = true
+	r.commands = append(r.commands, command)
+}
+
+// take returns the commands recorded so far and starts a new recording
+func (r *commandRecorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	commands := r.commands
+	r.commands = nil
+	r.seen = nil
+	return commands
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// writeCommands writes the commands recorded since the last call to filename
+func (c *Collector) writeCommands(filename string) error {
+	var output strings.Builder
+	output.WriteString("# kubectl equivalents of the API requests made for this archive, in order.\n")
+	output.WriteString("# nmcrun uses client-go directly; these commands are descriptive, not what was run.\n\n")
+	for _, command := range c.commands.take() {
+		output.WriteString(command + "\n")
+	}
+	return os.WriteFile(filename, []byte(c.redact(output.String())), 0644)
+}
+
+// kubectlEquivalent maps an API request to the kubectl command performing the same
+// read. It returns "" for requests that are not reads.
+func kubectlEquivalent(method string, u *url.URL) string {
+	if method != http.MethodGet {
+		return ""
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var group, version string
+	switch {
+	case len(parts) == 1 && parts[0] == "version":
+		return "kubectl version"
+	case parts[0] == "api" && len(parts) >= 2:
+		version, parts = parts[1], parts[2:]
+	case parts[0] == "apis" && len(parts) >= 3:
+		group, version, parts = parts[1], parts[2], parts[3:]
+	case parts[0] == "api" || parts[0] == "apis":
+		return "kubectl api-resources"
+	default:
+		return "kubectl get --raw " + shellQuote(u.RequestURI())
+	}
+	if len(parts) == 0 {
+		return "kubectl api-resources"
+	}
+
+	// A namespaces/<ns> prefix scopes the rest of the path, unless it is the namespace itself
+	var namespace string
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace, parts = parts[1], parts[2:]
+	}
+
+	resource := parts[0]
+	if group != "" {
+		resource = fmt.Sprintf("%s.%s.%s", resource, version, group)
+	}
+
+	query := u.Query()
+	args := []string{"kubectl"}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	switch {
+	case len(parts) == 3 && parts[0] == "pods" && parts[2] == "log":
+		args = append(args, "logs", parts[1])
+		if container := query.Get("container"); container != "" {
+			args = append(args, "-c", container)
+		}
+		if query.Get("previous") == "true" {
+			args = append(args, "--previous")
+		}
+		if query.Get("timestamps") == "true" {
+			args = append(args, "--timestamps")
+		}
+		if since := query.Get("sinceSeconds"); since != "" {
+			args = append(args, "--since="+since+"s")
+		}
+		if sinceTime := query.Get("sinceTime"); sinceTime != "" {
+			args = append(args, "--since-time="+sinceTime)
+		}
+		if tail := query.Get("tailLines"); tail != "" {
+			args = append(args, "--tail="+tail)
+		}
+		if limit := query.Get("limitBytes"); limit != "" {
+			args = append(args, "--limit-bytes="+limit)
+		}
+		return strings.Join(args, " ")
+	case len(parts) > 2:
+		// Other subresources have no dedicated kubectl verb
+		return "kubectl get --raw " + shellQuote(u.RequestURI())
+	case len(parts) == 2:
+		args = append(args, "get", resource, parts[1])
+	default:
+		args = append(args, "get", resource)
+		if namespace == "" {
+			// Ignored by kubectl for cluster-scoped resources
+			args = append(args, "-A")
+		}
+	}
+
+	if selector := query.Get("labelSelector"); selector != "" {
+		args = append(args, "-l", shellQuote(selector))
+	}
+	if selector := query.Get("fieldSelector"); selector != "" {
+		args = append(args, "--field-selector", shellQuote(selector))
+	}
+	args = append(args, "-o", "yaml")
+	return strings.Join(args, " ")
+}
+
+// shellQuote single-quotes s when it contains characters a shell would interpret
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`!&|;<>()*?[]{}~#") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+)
+
+// controlPlaneProbeTimeout bounds the control-plane reachability check of the test command
+const controlPlaneProbeTimeout = 10 * time.Second
+
+// testControlPlaneReachability GETs the control-plane URL from the runaiconfig, the
+// connection the RunAI cluster components depend on, through the proxy settings and
+// without certificate verification with --insecure. Any HTTP response means the control
+// plane is reachable from here; a 5xx response is reported as unhealthy.
+func (c *Collector) testControlPlaneReachability() error {
+	_, cpURL, _ := c.extractClusterInfo()
+	if cpURL == "" || cpURL == "unknown" {
+		console.Printf("  ⏭️  Control plane URL unknown, skipping reachability check\n")
+		return nil
+	}
+	if !strings.Contains(cpURL, "://") {
+		cpURL = "https://" + cpURL
+	}
+
+	console.Printf("  🎛️  Testing %s... ", cpURL)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.opts.Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	client := &http.Client{Timeout: controlPlaneProbeTimeout, Transport: transport}
+	defer client.CloseIdleConnections()
+
+	start := time.Now()
+	resp, err := client.Get(cpURL)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		console.Printf("❌ UNREACHABLE\n")
+		console.Printf("    %v\n", err)
+		if strings.Contains(err.Error(), "certificate") && !c.opts.Insecure {
+			console.Printf("    💡 Retry with --insecure to skip certificate verification of the control plane\n")
+		}
+		return exitError(ExitConnectivity, fmt.Errorf("control plane %s is unreachable: %w", cpURL, err))
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		console.Printf("⚠️  UNHEALTHY (HTTP %d, %s)\n", resp.StatusCode, latency)
+		return exitError(ExitConnectivity, fmt.Errorf("control plane %s answered HTTP %d", cpURL, resp.StatusCode))
+	}
+	console.Printf("✅ REACHABLE (HTTP %d, %s)\n", resp.StatusCode, latency)
+	return nil
+}
@@ -0,0 +1,354 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getPodEvents retrieves the events of every workload pod
+func (c *Collector) getPodEvents(namespace, workload, typeSafe string) (string, error) {
+	filename := fmt.Sprintf("%s_%s_events.txt", workload, typeSafe)
+	console.Printf("  📄 Getting Pod Events...\n")
+
+	pods, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pods found for workload: %s", workload)
+	}
+
+	var output strings.Builder
+	for _, pod := range pods.Items {
+		events, err := c.clientset.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.name=%s", pod.Name),
+		})
+		if err != nil {
+			output.WriteString(fmt.Sprintf("# Pod %s\n# Error retrieving events: %v\n\n", pod.Name, err))
+			continue
+		}
+
+		items := c.filterEvents(events.Items)
+		output.WriteString(fmt.Sprintf("# Pod %s (%d events)\n", pod.Name, len(items)))
+		output.WriteString(formatEvents(items))
+		output.WriteString("\n")
+	}
+
+	if err := os.WriteFile(filename, []byte(c.redact(output.String())), 0644); err != nil {
+		return "", err
+	}
+
+	console.Printf("    ✅ Pod events retrieved\n")
+	return filename, nil
+}
+
+// getPodDescribe retrieves describe-style text for every workload pod
+func (c *Collector) getPodDescribe(namespace, workload, typeSafe string) (string, error) {
+	filename := fmt.Sprintf("%s_%s_describe.txt", workload, typeSafe)
+	console.Printf("  📄 Getting Pod describe output...\n")
+
+	pods, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pods found for workload: %s", workload)
+	}
+
+	var sections []string
+	for i := range pods.Items {
+		sections = append(sections, describePod(&pods.Items[i]))
+	}
+
+	output := strings.Join(sections, "\n---\n\n")
+	if err := os.WriteFile(filename, []byte(c.redact(output)), 0644); err != nil {
+		return "", err
+	}
+
+	console.Printf("    ✅ Pod describe output retrieved\n")
+	return filename, nil
+}
+
+// getWorkloadNodes retrieves the YAML and a condition/taint summary of every node
+// hosting a workload pod; pods that are not scheduled yet are skipped
+func (c *Collector) getWorkloadNodes(namespace, workload, typeSafe string) ([]string, error) {
+	console.Printf("  📄 Getting Node information...\n")
+
+	pods, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the workload pods by the node they landed on
+	podsByNode := map[string][]string{}
+	var nodeNames []string
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" {
+			console.Printf("    ⏭️  Pod %s is not scheduled yet, skipping\n", pod.Name)
+			continue
+		}
+		if _, seen := podsByNode[pod.Spec.NodeName]; !seen {
+			nodeNames = append(nodeNames, pod.Spec.NodeName)
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod.Name)
+	}
+	if len(nodeNames) == 0 {
+		return nil, fmt.Errorf("no scheduled pods found for workload: %s", workload)
+	}
+	sort.Strings(nodeNames)
+
+	var outputFiles []string
+	var sections []string
+	for _, nodeName := range nodeNames {
+		node, err := c.clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			console.Printf("    ⚠️  Failed to get node %s: %v\n", nodeName, err)
+			sections = append(sections, fmt.Sprintf("Name:             %s\nError:            %v\n", nodeName, err))
+			continue
+		}
+
+		output, err := c.objectToYAML(node)
+		if err != nil {
+			console.Printf("    ⚠️  Failed to convert node %s to YAML: %v\n", nodeName, err)
+		} else {
+			filename := fmt.Sprintf("%s_%s_node_%s.yaml", workload, typeSafe, nodeName)
+			if err := os.WriteFile(filename, []byte(output), 0644); err != nil {
+				return outputFiles, err
+			}
+			outputFiles = append(outputFiles, filename)
+		}
+
+		sections = append(sections, describeNodeSummary(node, podsByNode[nodeName]))
+	}
+
+	filename := fmt.Sprintf("%s_%s_nodes-summary.txt", workload, typeSafe)
+	summary := strings.Join(sections, "\n---\n\n")
+	if err := os.WriteFile(filename, []byte(c.redact(summary)), 0644); err != nil {
+		return outputFiles, err
+	}
+	outputFiles = append(outputFiles, filename)
+
+	console.Printf("    ✅ Node information retrieved (%d node(s))\n", len(nodeNames))
+	return outputFiles, nil
+}
+
+// describeNodeSummary renders the node conditions, taints and capacity relevant to scheduling
+func describeNodeSummary(node *corev1.Node, workloadPods []string) string {
+	var output strings.Builder
+	field := func(name, value string) {
+		output.WriteString(fmt.Sprintf("%-18s%s\n", name+":", value))
+	}
+
+	field("Name", node.Name)
+	field("Workload Pods", strings.Join(workloadPods, ", "))
+	field("Unschedulable", fmt.Sprintf("%t", node.Spec.Unschedulable))
+	field("Kubelet Version", valueOrNone(node.Status.NodeInfo.KubeletVersion))
+	field("Allocatable", formatResourceList(node.Status.Allocatable))
+
+	output.WriteString("Conditions:\n")
+	output.WriteString("  TYPE\tSTATUS\tREASON\tMESSAGE\n")
+	for _, condition := range node.Status.Conditions {
+		output.WriteString(fmt.Sprintf("  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, valueOrNone(condition.Reason), condition.Message))
+	}
+
+	output.WriteString("Taints:\n")
+	if len(node.Spec.Taints) == 0 {
+		output.WriteString("  <none>\n")
+	}
+	for _, taint := range node.Spec.Taints {
+		output.WriteString(fmt.Sprintf("  %s\n", taint.ToString()))
+	}
+
+	return output.String()
+}
+
+// filterEvents drops events last seen before the events window
+func (c *Collector) filterEvents(events []corev1.Event) []corev1.Event {
+	cutoff, limited := c.opts.eventsCutoff(c.startTime)
+	return eventsSince(events, cutoff, limited)
+}
+
+// eventsSince drops events last seen before cutoff, if limited
+func eventsSince(events []corev1.Event, cutoff time.Time, limited bool) []corev1.Event {
+	if !limited {
+		return events
+	}
+
+	var filtered []corev1.Event
+	for _, event := range events {
+		if !eventTime(event).Before(cutoff) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// formatEvents formats events oldest first, similar to kubectl get events
+func formatEvents(events []corev1.Event) string {
+	sort.Slice(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+
+	var output strings.Builder
+	output.WriteString("LAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE\n")
+	for _, event := range events {
+		lastSeen := "<unknown>"
+		if t := eventTime(event); !t.IsZero() {
+			lastSeen = t.Format(time.RFC3339)
+		}
+		output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s/%s\t%d\t%s\n",
+			lastSeen,
+			event.Type,
+			event.Reason,
+			strings.ToLower(event.InvolvedObject.Kind),
+			event.InvolvedObject.Name,
+			event.Count,
+			strings.TrimSpace(event.Message),
+		))
+	}
+	return output.String()
+}
+
+// eventTime returns the most relevant timestamp of an event
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
+
+// describePod renders a pod in a layout similar to kubectl describe pod
+func describePod(pod *corev1.Pod) string {
+	var output strings.Builder
+	field := func(name, value string) {
+		output.WriteString(fmt.Sprintf("%-18s%s\n", name+":", value))
+	}
+
+	field("Name", pod.Name)
+	field("Namespace", pod.Namespace)
+	field("Priority Class", valueOrNone(pod.Spec.PriorityClassName))
+	field("Scheduler", valueOrNone(pod.Spec.SchedulerName))
+	field("Node", valueOrNone(pod.Spec.NodeName))
+	if pod.Status.StartTime != nil {
+		field("Start Time", pod.Status.StartTime.Format(time.RFC3339))
+	}
+	field("Status", string(pod.Status.Phase))
+	if pod.Status.Reason != "" {
+		field("Reason", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		field("Message", pod.Status.Message)
+	}
+	field("IP", valueOrNone(pod.Status.PodIP))
+	field("QoS Class", string(pod.Status.QOSClass))
+	field("Labels", formatStringMap(pod.Labels))
+	field("Node-Selectors", formatStringMap(pod.Spec.NodeSelector))
+
+	writeContainers := func(title string, containers []corev1.Container, statuses []corev1.ContainerStatus) {
+		if len(containers) == 0 {
+			return
+		}
+		output.WriteString(title + ":\n")
+		for _, container := range containers {
+			output.WriteString(fmt.Sprintf("  %s:\n", container.Name))
+			output.WriteString(fmt.Sprintf("    Image:          %s\n", container.Image))
+			for _, status := range statuses {
+				if status.Name != container.Name {
+					continue
+				}
+				state, reason, exitCode := describeContainerState(status.State)
+				output.WriteString(fmt.Sprintf("    State:          %s (reason: %s, exit code: %s)\n", state, reason, exitCode))
+				lastState, lastReason, lastExitCode := describeContainerState(status.LastTerminationState)
+				output.WriteString(fmt.Sprintf("    Last State:     %s (reason: %s, exit code: %s)\n", lastState, lastReason, lastExitCode))
+				output.WriteString(fmt.Sprintf("    Ready:          %t\n", status.Ready))
+				output.WriteString(fmt.Sprintf("    Restart Count:  %d\n", status.RestartCount))
+			}
+			output.WriteString(fmt.Sprintf("    Requests:       %s\n", formatResourceList(container.Resources.Requests)))
+			output.WriteString(fmt.Sprintf("    Limits:         %s\n", formatResourceList(container.Resources.Limits)))
+		}
+	}
+	writeContainers("Init Containers", pod.Spec.InitContainers, pod.Status.InitContainerStatuses)
+	writeContainers("Containers", pod.Spec.Containers, pod.Status.ContainerStatuses)
+
+	output.WriteString("Conditions:\n")
+	output.WriteString("  TYPE\tSTATUS\tREASON\tMESSAGE\n")
+	for _, condition := range pod.Status.Conditions {
+		output.WriteString(fmt.Sprintf("  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, valueOrNone(condition.Reason), condition.Message))
+	}
+
+	output.WriteString("Tolerations:\n")
+	if len(pod.Spec.Tolerations) == 0 {
+		output.WriteString("  <none>\n")
+	}
+	for _, toleration := range pod.Spec.Tolerations {
+		output.WriteString(fmt.Sprintf("  %s\n", formatToleration(toleration)))
+	}
+
+	return output.String()
+}
+
+// formatToleration renders a toleration like kubectl describe does
+func formatToleration(toleration corev1.Toleration) string {
+	text := toleration.Key
+	if toleration.Operator == corev1.TolerationOpExists {
+		text += " op=Exists"
+	} else if toleration.Value != "" {
+		text += "=" + toleration.Value
+	}
+	if toleration.Effect != "" {
+		text += ":" + string(toleration.Effect)
+	}
+	if toleration.TolerationSeconds != nil {
+		text += fmt.Sprintf(" for %ds", *toleration.TolerationSeconds)
+	}
+	if text == "" {
+		text = "<all> op=Exists"
+	}
+	return text
+}
+
+// formatStringMap renders a map as sorted key=value pairs
+func formatStringMap(values map[string]string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	var pairs []string
+	for key, value := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// formatResourceList renders resource quantities as sorted name=quantity pairs
+func formatResourceList(resources corev1.ResourceList) string {
+	if len(resources) == 0 {
+		return "<none>"
+	}
+	var pairs []string
+	for name, quantity := range resources {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// valueOrNone returns value, or "<none>" when it is empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,161 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Diagnosis severities; RED findings make the test command fail
+const (
+	severityYellow = "YELLOW"
+	severityRed    = "RED"
+)
+
+// finding is a single diagnosis result with a remediation hint
+type finding struct {
+	severity string
+	problem  string
+	hint     string
+}
+
+// diagnose checks the cluster for common RunAI problems
+func (c *Collector) diagnose() []finding {
+	var findings []finding
+
+	// Cluster information is needed for archive naming and support triage
+	clusterURL, cpURL, _ := c.extractClusterInfo()
+	if cpURL == "unknown" || cpURL == "" {
+		findings = append(findings, finding{severityRed,
+			"Control plane URL is unknown",
+			"Check that the runaiconfig resource exists and sets spec.__internal.global.controlPlane.url"})
+	}
+	if clusterURL == "unknown" || clusterURL == "" {
+		findings = append(findings, finding{severityYellow,
+			"Cluster URL is unknown",
+			"Check that the runaiconfig resource sets spec.__internal.global.clusterURL"})
+	}
+
+	// RunAI components should be running
+	runaiNamespace := c.findRunAINamespace()
+	if exists, err := c.namespaceExists(runaiNamespace); err != nil {
+		findings = append(findings, finding{severityYellow,
+			fmt.Sprintf("Cannot check namespace '%s': %v", runaiNamespace, err),
+			"Check that your credentials are allowed to get namespaces (kubectl auth can-i get namespaces)"})
+	} else if exists {
+		pods, err := c.getPods(runaiNamespace)
+		if err == nil && len(pods) == 0 {
+			findings = append(findings, finding{severityRed,
+				fmt.Sprintf("No pods found in namespace '%s'", runaiNamespace),
+				"Verify the RunAI cluster installation (helm release status and operator logs)"})
+		}
+	}
+
+	// Versions reported by the runaiconfig and the runai-public configmap should agree
+	if configVersion, clusterVersion := c.getRunAIVersions(runaiNamespace); configVersion != "" && clusterVersion != "" &&
+		!strings.Contains(clusterVersion, strings.TrimPrefix(configVersion, "v")) {
+		findings = append(findings, finding{severityYellow,
+			fmt.Sprintf("RunAI version mismatch: runaiconfig reports %s, runai-public reports %s", configVersion, clusterVersion),
+			"An upgrade may be incomplete; check the runai operator logs and helm release history"})
+	}
+
+	// Pods that are not ready or crash looping
+	for _, namespace := range []string{runaiNamespace, "runai-backend"} {
+		pods, err := c.listPods(namespace, metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+
+		var notReady, crashLooping []string
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodSucceeded {
+				continue
+			}
+			if isCrashLooping(&pod) {
+				crashLooping = append(crashLooping, pod.Name)
+			} else if !isPodReady(&pod) {
+				notReady = append(notReady, pod.Name)
+			}
+		}
+
+		if len(crashLooping) > 0 {
+			findings = append(findings, finding{severityRed,
+				fmt.Sprintf("%d pod(s) in CrashLoopBackOff in '%s': %s", len(crashLooping), namespace, strings.Join(crashLooping, ", ")),
+				"Run 'nmcrun logs --crashing-only' to collect current and previous logs for these pods"})
+		}
+		if len(notReady) > 0 {
+			findings = append(findings, finding{severityYellow,
+				fmt.Sprintf("%d pod(s) not Ready in '%s': %s", len(notReady), namespace, strings.Join(notReady, ", ")),
+				"Check pod events and readiness probes (kubectl describe pod)"})
+		}
+	}
+
+	return findings
+}
+
+// getRunAIVersions gets the RunAI version from the runaiconfig image tag and the runai-public configmap
+func (c *Collector) getRunAIVersions(namespace string) (string, string) {
+	var configVersion, clusterVersion string
+
+	if obj, err := c.getRunAIConfig(); err == nil {
+		if version, found, _ := unstructured.NestedString(obj.Object, "spec", "global", "image", "tag"); found {
+			configVersion = strings.TrimSpace(version)
+		}
+	}
+
+	if cm, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), "runai-public", metav1.GetOptions{}); err == nil {
+		clusterVersion = strings.TrimSpace(cm.Data["cluster-version"])
+	}
+
+	return configVersion, clusterVersion
+}
+
+// isCrashLooping reports whether any container in the pod is in CrashLoopBackOff
+func isCrashLooping(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodReady reports whether the pod's Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// printDiagnosis prints the diagnosis findings and returns an error if any are RED
+func (c *Collector) printDiagnosis(findings []finding) error {
+	if len(findings) == 0 {
+		console.Println("  🟢 No common problems detected")
+		return nil
+	}
+
+	red := 0
+	for _, f := range findings {
+		icon := "🟡"
+		if f.severity == severityRed {
+			icon = "🔴"
+			red++
+		}
+		console.Printf("  %s %s: %s\n", icon, f.severity, f.problem)
+		console.Printf("     💡 %s\n", f.hint)
+	}
+
+	if red > 0 {
+		return fmt.Errorf("diagnosis found %d critical problem(s)", red)
+	}
+	return nil
+}
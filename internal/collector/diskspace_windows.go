@@ -0,0 +1,16 @@
+package collector
+
+import "golang.org/x/sys/windows"
+
+// availableDiskSpace returns the bytes available to the current user on the volume of dir
+func availableDiskSpace(dir string) (uint64, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+	var available, total, free uint64
+	if err := windows.GetDiskFreeSpaceEx(path, &available, &total, &free); err != nil {
+		return 0, err
+	}
+	return available, nil
+}
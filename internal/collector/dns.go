@@ -0,0 +1,96 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"nmcrun/internal/console"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Default location of the cluster DNS pods; CoreDNS keeps the kube-dns label for compatibility
+const (
+	DefaultDNSNamespace = "kube-system"
+	DefaultDNSSelector  = "k8s-app=kube-dns"
+)
+
+// collectDNSInfo saves the cluster DNS pod logs and the coredns ConfigMap to dns/, for
+// diagnosing RunAI components that fail to resolve the control plane hostname
+func (c *Collector) collectDNSInfo(logDir string, scriptLog io.Writer) {
+	namespace := c.opts.DNSNamespace
+	if namespace == "" {
+		namespace = DefaultDNSNamespace
+	}
+	selector := c.opts.DNSSelector
+	if selector == "" {
+		selector = DefaultDNSSelector
+	}
+
+	console.Printf("  🌐 Collecting cluster DNS information from %s (%s)...\n", namespace, selector)
+	fmt.Fprintf(scriptLog, "Collecting cluster DNS information from %s (%s)...\n", namespace, selector)
+
+	dnsDir := filepath.Join(logDir, "dns")
+	if err := os.MkdirAll(dnsDir, 0755); err != nil {
+		c.failStep(namespace, "dns", "cluster DNS information", scriptLog, err, nil)
+		return
+	}
+
+	c.runAction(namespace, dnsDir, scriptLog, collectAction{"ConfigMap coredns", "cm_coredns.yaml", func() (string, error) {
+		return c.getConfigMap(namespace, "coredns")
+	}})
+
+	pods, err := c.listPods(namespace, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		c.failStep(namespace, "dns", "DNS pod logs", scriptLog, err, func() error {
+			return c.writeDNSLogs(namespace, selector, dnsDir)
+		})
+		return
+	}
+	if len(pods) == 0 {
+		console.Printf("    ⚠️  Warning: No DNS pods match %s in %s (set --dns-namespace/--dns-selector)\n", selector, namespace)
+		fmt.Fprintf(scriptLog, "  ⚠ Warning: No DNS pods match %s in %s\n", selector, namespace)
+		return
+	}
+
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			logFile := filepath.Join(dnsDir, fmt.Sprintf("%s_%s.log", pod.Name, container.Name))
+			savedFile, err := c.collectContainerLogs(pod.Name, container.Name, namespace, logFile, false)
+			c.emit(progressEvent{Phase: "podlogs", Namespace: namespace, Pod: pod.Name, Container: container.Name}, err)
+			if err != nil {
+				console.Printf("    ⚠️  Warning: Failed to collect logs for %s/%s: %v\n", pod.Name, container.Name, err)
+				fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to collect logs for %s/%s: %v\n", pod.Name, container.Name, err)
+				pod, container := pod.Name, container.Name
+				c.recordFailure(fmt.Sprintf("DNS logs for %s/%s", pod, container), err, func() error {
+					_, err := c.collectContainerLogs(pod, container, namespace, logFile, false)
+					return err
+				})
+				continue
+			}
+			console.Printf("    ✅ DNS logs saved: %s/%s\n", pod.Name, container.Name)
+			fmt.Fprintf(scriptLog, "  ✓ DNS logs saved to: %s\n", savedFile)
+		}
+	}
+}
+
+// writeDNSLogs collects the logs of every cluster DNS pod to dnsDir
+func (c *Collector) writeDNSLogs(namespace, selector, dnsDir string) error {
+	pods, err := c.listPods(namespace, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			logFile := filepath.Join(dnsDir, fmt.Sprintf("%s_%s.log", pod.Name, container.Name))
+			if _, err := c.collectContainerLogs(pod.Name, container.Name, namespace, logFile, false); err != nil {
+				errs = append(errs, fmt.Errorf("%s/%s: %w", pod.Name, container.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
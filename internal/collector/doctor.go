@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"nmcrun/internal/console"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Disk space thresholds of the doctor command. A collection keeps the log directory until
+// its archive is verified, so a busy cluster can briefly need twice its log volume.
+const (
+	doctorDiskEstimate = 2 << 30
+	doctorDiskMinimum  = 256 << 20
+)
+
+// doctorStatus is the outcome of a doctor check
+type doctorStatus int
+
+const (
+	doctorOK doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorResult is the outcome of a doctor check, with a remediation unless it passed
+type doctorResult struct {
+	status      doctorStatus
+	detail      string
+	remediation string
+}
+
+// Doctor checks the local prerequisites of a collection (kubeconfig, authentication,
+// output directory and disk space) and, through updateCheck, the update server. It prints
+// a checklist with a remediation for every problem and fails if any check failed.
+func Doctor(outputDir string, updateCheck func() (string, error)) error {
+	console.Println("🩺 Checking local prerequisites...")
+
+	checks := []struct {
+		name string
+		run  func() doctorResult
+	}{
+		{"Kubeconfig", checkKubeconfig},
+		{"Authentication method", checkAuthMethod},
+		{"Output directory", func() doctorResult { return checkOutputDir(outputDir) }},
+		{"Disk space", func() doctorResult { return checkDiskSpace(outputDir) }},
+		{"Update server", func() doctorResult {
+			detail, err := updateCheck()
+			if err != nil {
+				return doctorResult{doctorWarn, err.Error(), "Check the network and proxy (--proxy, HTTPS_PROXY), or disable update checks with --offline"}
+			}
+			return doctorResult{status: doctorOK, detail: detail}
+		}},
+	}
+
+	warnings, failures := 0, 0
+	for _, check := range checks {
+		result := check.run()
+		switch result.status {
+		case doctorOK:
+			console.Printf("  ✅ %s: %s\n", check.name, result.detail)
+			continue
+		case doctorWarn:
+			console.Printf("  ⚠️  %s: %s\n", check.name, result.detail)
+			warnings++
+		case doctorFail:
+			console.Printf("  ❌ %s: %s\n", check.name, result.detail)
+			failures++
+		}
+		console.Printf("     → %s\n", result.remediation)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d check(s) failed, %d warning(s)", failures, warnings)
+	}
+	if warnings > 0 {
+		console.Printf("🟡 All checks passed with %d warning(s)\n", warnings)
+		return nil
+	}
+	console.Println("🟢 All checks passed")
+	return nil
+}
+
+// checkKubeconfig checks that every kubeconfig file is readable and parses, and that the
+// merged configuration has a usable current context
+func checkKubeconfig() doctorResult {
+	var files []string
+	for _, file := range clientcmd.NewDefaultClientConfigLoadingRules().GetLoadingPrecedence() {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			continue
+		}
+		if _, err := os.ReadFile(file); err != nil {
+			return doctorResult{doctorFail, fmt.Sprintf("cannot read %s: %v", file, err), fmt.Sprintf("Fix the permissions of %s (e.g. chmod 600 and chown to your user)", file)}
+		}
+		if _, err := clientcmd.LoadFromFile(file); err != nil {
+			return doctorResult{doctorFail, fmt.Sprintf("%s is not a valid kubeconfig: %v", file, err), "Fix the file or download the cluster kubeconfig again"}
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return doctorResult{doctorWarn, "no kubeconfig file found", "Set KUBECONFIG or place the cluster kubeconfig at ~/.kube/config (not needed when running in a pod)"}
+	}
+
+	loader := newKubeconfigLoader()
+	contextName, err := currentContextName(loader)
+	if err != nil {
+		return doctorResult{doctorFail, err.Error(), "Select a context with 'kubectl config use-context <name>'"}
+	}
+	config, err := loader.ClientConfig()
+	if err != nil {
+		return doctorResult{doctorFail, fmt.Sprintf("context %s is invalid: %v", contextName, err), "Fix the context, or select another one with 'kubectl config use-context <name>'"}
+	}
+
+	// Exec plugins (cloud CLIs, kubelogin) fail only when the first request is made
+	if config.ExecProvider != nil {
+		if _, err := exec.LookPath(config.ExecProvider.Command); err != nil {
+			return doctorResult{doctorFail, fmt.Sprintf("context %s needs the credential plugin %q, which is not in PATH", contextName, config.ExecProvider.Command), fmt.Sprintf("Install %s or add it to PATH", config.ExecProvider.Command)}
+		}
+	}
+	return doctorResult{status: doctorOK, detail: fmt.Sprintf("context %s, server %s (%s)", contextName, config.Host, strings.Join(files, ", "))}
+}
+
+// checkAuthMethod reports the authentication method getKubernetesConfig selects, and why
+// the methods tried before it were skipped
+func checkAuthMethod() doctorResult {
+	var skipped []string
+	for _, method := range authMethods {
+		if _, err := method.try(); err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", method.description, err))
+			continue
+		}
+		detail := method.description
+		if len(skipped) > 0 {
+			detail += fmt.Sprintf(" (skipped %s)", strings.Join(skipped, "; "))
+		}
+		return doctorResult{status: doctorOK, detail: detail}
+	}
+	return doctorResult{doctorFail, "no authentication method works: " + strings.Join(skipped, "; "), "Provide a kubeconfig (KUBECONFIG or ~/.kube/config), or run in a pod with a service account"}
+}
+
+// checkOutputDir checks that collections can be written to dir
+func checkOutputDir(dir string) doctorResult {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		absDir = dir
+	}
+	file, err := os.CreateTemp(dir, ".nmcrun-doctor-")
+	if err != nil {
+		return doctorResult{doctorFail, fmt.Sprintf("%s is not writable: %v", absDir, err), "Run nmcrun from a writable directory"}
+	}
+	file.Close()
+	os.Remove(file.Name())
+	return doctorResult{status: doctorOK, detail: fmt.Sprintf("%s is writable", absDir)}
+}
+
+// checkDiskSpace compares the space available in dir with what a large collection needs
+func checkDiskSpace(dir string) doctorResult {
+	available, err := availableDiskSpace(dir)
+	if err != nil {
+		return doctorResult{doctorWarn, fmt.Sprintf("cannot determine free space: %v", err), "Make sure a few GiB are free before collecting"}
+	}
+
+	detail := fmt.Sprintf("%d MiB available, a large collection needs up to %d MiB", available>>20, doctorDiskEstimate>>20)
+	switch {
+	case available < doctorDiskMinimum:
+		return doctorResult{doctorFail, detail, "Free up space or run from another file system"}
+	case available < doctorDiskEstimate:
+		return doctorResult{doctorWarn, detail, "Free up space, or collect less data (e.g. --since or --max-archive-bytes)"}
+	}
+	return doctorResult{status: doctorOK, detail: detail}
+}
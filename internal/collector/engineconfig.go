@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// engineConfigKnobs groups the scheduling settings support usually looks for in the
+// engine config; a setting belongs to a group when any segment of its path contains
+// one of the keywords (case-insensitive)
+var engineConfigKnobs = []struct {
+	group    string
+	keywords []string
+}{
+	{"Fairness", []string{"fair", "overquota", "quota", "reclaim"}},
+	{"Preemption", []string{"preempt", "evict"}},
+	{"Bin-packing / placement", []string{"binpack", "spread", "placement", "consolidat", "strategy"}},
+}
+
+// getEngineConfigSummary extracts the key scheduling knobs from the engine config in namespace
+func (c *Collector) getEngineConfigSummary(namespace string) (string, error) {
+	output, err := c.getResourceAsYAML(namespace, "configs.engine.run.ai", "engine-config")
+	if err != nil {
+		return "", err
+	}
+
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(output), &obj); err != nil {
+		return "", fmt.Errorf("failed to parse engine config: %w", err)
+	}
+
+	spec, found, err := unstructured.NestedMap(obj, "spec")
+	if err != nil {
+		return "", fmt.Errorf("failed to read engine config spec: %w", err)
+	}
+	if !found {
+		spec = map[string]interface{}{}
+	}
+
+	settings := map[string]string{}
+	flattenSettings("spec", spec, settings)
+
+	return formatEngineConfigSummary(settings), nil
+}
+
+// flattenSettings collects the leaf values of a nested object keyed by dotted path
+func flattenSettings(prefix string, value interface{}, settings map[string]string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			flattenSettings(prefix+"."+key, child, settings)
+		}
+	case []interface{}:
+		for i, child := range typed {
+			flattenSettings(fmt.Sprintf("%s[%d]", prefix, i), child, settings)
+		}
+	default:
+		settings[prefix] = fmt.Sprintf("%v", typed)
+	}
+}
+
+// formatEngineConfigSummary renders the settings matching each knob group;
+// groups without explicit settings are reported as using the defaults
+func formatEngineConfigSummary(settings map[string]string) string {
+	var paths []string
+	for path := range settings {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var output strings.Builder
+	output.WriteString("# Engine config scheduling summary (from engine-config.yaml)\n")
+	for _, knob := range engineConfigKnobs {
+		output.WriteString(fmt.Sprintf("\n%s:\n", knob.group))
+
+		matched := 0
+		for _, path := range paths {
+			if matchesKeyword(strings.ToLower(path), knob.keywords) {
+				output.WriteString(fmt.Sprintf("  %s: %s\n", path, settings[path]))
+				matched++
+			}
+		}
+		if matched == 0 {
+			output.WriteString("  default (no explicit settings)\n")
+		}
+	}
+	return output.String()
+}
+
+// matchesKeyword reports whether the text contains any of the keywords
+func matchesKeyword(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
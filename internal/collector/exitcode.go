@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"errors"
+)
+
+// Exit codes of the test and logs commands, so they can gate CI pipelines. When several
+// problems apply, the lowest of codes 2-5 is reported.
+const (
+	ExitOK                = 0
+	ExitFailure           = 1 // any other failure
+	ExitConnectivity      = 2 // the cluster cannot be reached
+	ExitNamespacesMissing = 3 // a requested RunAI namespace does not exist
+	ExitPartial           = 4 // some data could not be collected, e.g. Forbidden by RBAC
+	ExitConfigUnreadable  = 5 // the runaiconfig resource cannot be read
+)
+
+// ExitError is an error carrying the process exit code it maps to
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code for an error returned by the collector
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+// exitError attaches an exit code to err
+func exitError(code int, err error) error {
+	return &ExitError{Code: code, Err: err}
+}
+
+// lowestExitCode returns the lowest non-zero code, or ExitOK when there is none
+func lowestExitCode(codes ...int) int {
+	lowest := ExitOK
+	for _, code := range codes {
+		if code != ExitOK && (lowest == ExitOK || code < lowest) {
+			lowest = code
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,104 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"nmcrun/internal/console"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// extraResource is a resource requested with --extra-resource, optionally a single object
+type extraResource struct {
+	gvr  schema.GroupVersionResource
+	name string
+}
+
+// parseExtraResources parses group/version/resource[:name] specs; core resources are
+// given as version/resource (e.g. v1/configmaps)
+func parseExtraResources(specs []string) ([]extraResource, error) {
+	var resources []extraResource
+	for _, spec := range specs {
+		path, name, _ := strings.Cut(spec, ":")
+		parts := strings.Split(path, "/")
+		var gvr schema.GroupVersionResource
+		switch len(parts) {
+		case 2:
+			gvr = schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}
+		case 3:
+			gvr = schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}
+		}
+		if gvr.Version == "" || gvr.Resource == "" || (strings.Contains(spec, ":") && name == "") {
+			return nil, fmt.Errorf("invalid extra resource %q (expected group/version/resource[:name], e.g. run.ai/v2alpha1/projects)", spec)
+		}
+		if gvr.Group == "" && gvr.Resource == "secrets" {
+			return nil, fmt.Errorf("invalid extra resource %q: secrets are not collected, they hold credentials", spec)
+		}
+		resources = append(resources, extraResource{gvr: gvr, name: name})
+	}
+	return resources, nil
+}
+
+// filename is the file an extra resource is written to, e.g. extra_projects.run.ai.yaml
+func (r extraResource) filename() string {
+	filename := "extra_" + r.gvr.GroupResource().String()
+	if r.name != "" {
+		filename += "_" + r.name
+	}
+	return filename + ".yaml"
+}
+
+// collectExtraResources writes the --extra-resource resources to dir. In a namespace,
+// namespaced resources are read from it and cluster-scoped ones are only written with the
+// RunAI namespace; without a namespace, every namespace is read.
+func (c *Collector) collectExtraResources(namespace, dir string, scriptLog io.Writer) {
+	for _, resource := range c.extraResources {
+		namespaced, known := c.isNamespaced(resource.gvr)
+		if known && !namespaced && namespace != "" && namespace != c.findRunAINamespace() {
+			continue
+		}
+
+		name := resource.gvr.GroupResource().String()
+		if resource.name != "" {
+			name += "/" + resource.name
+		}
+		console.Printf("  📊 Collecting extra resource %s...\n", name)
+		fmt.Fprintf(scriptLog, "Collecting extra resource %s...\n", name)
+
+		c.runAction(namespace, dir, scriptLog, collectAction{"Extra resource " + name, resource.filename(), func() (string, error) {
+			return c.getExtraResourceYAML(resource, namespace, namespaced, known)
+		}})
+	}
+}
+
+// getExtraResourceYAML gets an extra resource, or the list of all of them, as YAML
+func (c *Collector) getExtraResourceYAML(resource extraResource, namespace string, namespaced, known bool) (string, error) {
+	if !known {
+		return "", fmt.Errorf("%s is not served by the API server", resource.gvr)
+	}
+	if !namespaced {
+		namespace = ""
+	}
+	client := c.resourceClient(resource.gvr, namespace)
+
+	var obj runtime.Object
+	var err error
+	switch {
+	case resource.name == "":
+		obj, err = client.List(context.TODO(), metav1.ListOptions{})
+	case namespaced && namespace == "":
+		// A namespaced object is looked up by name across all namespaces
+		obj, err = client.List(context.TODO(), metav1.ListOptions{FieldSelector: "metadata.name=" + resource.name})
+	default:
+		obj, err = client.Get(context.TODO(), resource.name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(obj)
+}
@@ -0,0 +1,149 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// gpuResource is the extended resource of whole NVIDIA GPUs
+const gpuResource corev1.ResourceName = "nvidia.com/gpu"
+
+// RunAI pod annotations describing fractional GPU requests and the GPUs allocated to a pod
+const (
+	gpuMemoryAnnotation     = "gpu-memory"
+	allocatedGPUsAnnotation = "runai-allocated-gpus"
+	gpuIndexAnnotation      = "runai-gpu"
+	gpuGroupAnnotation      = "runai-gpu-group"
+)
+
+// reservationNamespace holds the RunAI reservation pods, which each request a whole GPU
+// to back the fractional pods sharing it
+const reservationNamespace = "runai-reservation"
+
+// nodeGPUs is the GPU capacity of a node and what its active pods request
+type nodeGPUs struct {
+	allocatable int64
+	whole       int64
+	fractional  float64
+	reserved    int64
+	pods        int
+}
+
+// getGPUAllocation maps every active pod requesting GPUs to its node, its whole or fractional
+// GPU request and the GPUs RunAI allocated to it, then sums the requests per node against
+// the node's allocatable GPUs
+func (c *Collector) getGPUAllocation() (string, error) {
+	nodes, err := c.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+	pods, err := c.listPods("", metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	perNode := map[string]*nodeGPUs{}
+	for _, node := range nodes.Items {
+		allocatable := node.Status.Allocatable[gpuResource]
+		perNode[node.Name] = &nodeGPUs{allocatable: allocatable.Value()}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Spec.NodeName != pods[j].Spec.NodeName {
+			return pods[i].Spec.NodeName < pods[j].Spec.NodeName
+		}
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	var output strings.Builder
+	output.WriteString("# Active pods requesting GPUs; GPUS is the nvidia.com/gpu limit (or request), FRACTION and GPU-MEMORY the RunAI fractional request, ALLOCATED the GPUs RunAI assigned\n")
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tNAMESPACE\tPOD\tPHASE\tGPUS\tFRACTION\tGPU-MEMORY\tALLOCATED\tGPU-GROUP")
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		var whole int64
+		for _, container := range pod.Spec.Containers {
+			gpus, found := container.Resources.Limits[gpuResource]
+			if !found {
+				gpus = container.Resources.Requests[gpuResource]
+			}
+			whole += gpus.Value()
+		}
+		fraction, fractionErr := strconv.ParseFloat(pod.Annotations[gpuFractionAnnotation], 64)
+		gpuMemory := pod.Annotations[gpuMemoryAnnotation]
+		if whole == 0 && fractionErr != nil && gpuMemory == "" {
+			continue
+		}
+
+		allocated := pod.Annotations[allocatedGPUsAnnotation]
+		if allocated == "" {
+			allocated = pod.Annotations[gpuIndexAnnotation]
+		}
+		fractionText := "-"
+		if fractionErr == nil {
+			fractionText = strconv.FormatFloat(fraction, 'f', -1, 64)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", valueOrNone(pod.Spec.NodeName), pod.Namespace, pod.Name, pod.Status.Phase,
+			whole, fractionText, valueOrNone(gpuMemory), valueOrNone(allocated), valueOrNone(pod.Annotations[gpuGroupAnnotation]))
+
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		node := perNode[pod.Spec.NodeName]
+		if node == nil {
+			node = &nodeGPUs{}
+			perNode[pod.Spec.NodeName] = node
+		}
+		// The GPU of a reservation pod is already requested by the fractions sharing it
+		if pod.Namespace == reservationNamespace {
+			node.reserved += whole
+			continue
+		}
+		node.pods++
+		node.whole += whole
+		if fractionErr == nil {
+			node.fractional += fraction
+		}
+	}
+	w.Flush()
+
+	output.WriteString("\n=== Per node ===\n")
+	output.WriteString("# REQUESTED is whole GPUs plus the sum of fractions; GPU-memory requests are not included.\n")
+	output.WriteString(fmt.Sprintf("# RESERVED is the GPUs held for fractional pods by reservation pods in %s, not counted again in REQUESTED\n", reservationNamespace))
+	w = tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tALLOCATABLE\tPODS\tWHOLE\tFRACTIONAL\tRESERVED\tREQUESTED\tFREE")
+	var names []string
+	for name, node := range perNode {
+		if node.allocatable > 0 || node.pods > 0 || node.reserved > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		node := perNode[name]
+		requested := float64(node.whole) + node.fractional
+		free := strconv.FormatFloat(float64(node.allocatable)-requested, 'f', -1, 64)
+		if requested > float64(node.allocatable) {
+			free += " (overcommitted)"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%d\t%s\t%s\n", name, node.allocatable, node.pods, node.whole,
+			strconv.FormatFloat(node.fractional, 'f', -1, 64), node.reserved, strconv.FormatFloat(requested, 'f', -1, 64), free)
+	}
+	w.Flush()
+
+	return output.String(), nil
+}
@@ -0,0 +1,269 @@
+package collector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// helmRelease holds the fields of a Helm v3 release object that are collected
+type helmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		Status       string `json:"status"`
+		LastDeployed string `json:"last_deployed"`
+		Description  string `json:"description"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+	} `json:"chart"`
+	// Config is the user-supplied values the release was installed or upgraded with
+	Config map[string]interface{} `json:"config"`
+}
+
+// sensitiveValueKey matches Helm value keys whose values are masked in helm-values files
+var sensitiveValueKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private_?key|api_?key|access_?key)`)
+
+// decodeHelmRelease decodes the release stored in a helm.sh/release.v1 secret:
+// base64-encoded, usually gzipped, JSON
+func decodeHelmRelease(secret *corev1.Secret) (*helmRelease, error) {
+	data, err := base64.StdEncoding.DecodeString(string(secret.Data["release"]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode release %s: %w", secret.Name, err)
+	}
+
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress release %s: %w", secret.Name, err)
+		}
+		defer reader.Close()
+		if data, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("failed to decompress release %s: %w", secret.Name, err)
+		}
+	}
+
+	var release helmRelease
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse release %s: %w", secret.Name, err)
+	}
+	return &release, nil
+}
+
+// latestHelmReleases returns the highest revision secret of each Helm release in the namespace
+func (c *Collector) latestHelmReleases(namespace string) ([]corev1.Secret, error) {
+	names, history, err := c.helmReleaseHistory(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []corev1.Secret
+	for _, name := range names {
+		revisions := history[name]
+		releases = append(releases, revisions[len(revisions)-1])
+	}
+	return releases, nil
+}
+
+// helmReleaseHistory returns the Helm release names in the namespace, in the order they
+// were listed, and the revision secrets of each release sorted by revision
+func (c *Collector) helmReleaseHistory(namespace string) ([]string, map[string][]corev1.Secret, error) {
+	secrets, err := c.clientset.CoreV1().Secrets(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "owner=helm",
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list Helm secrets: %w", err)
+	}
+
+	var names []string
+	history := map[string][]corev1.Secret{}
+	for _, secret := range secrets.Items {
+		name := secret.Labels["name"]
+		if name == "" || secret.Type != "helm.sh/release.v1" {
+			continue
+		}
+		if _, exists := history[name]; !exists {
+			names = append(names, name)
+		}
+		history[name] = append(history[name], secret)
+	}
+
+	for _, revisions := range history {
+		sort.SliceStable(revisions, func(i, j int) bool {
+			return helmRevision(revisions[i]) < helmRevision(revisions[j])
+		})
+	}
+	return names, history, nil
+}
+
+// helmRevision returns the release revision from the secret's version label
+func helmRevision(secret corev1.Secret) int {
+	revision, _ := strconv.Atoi(secret.Labels["version"])
+	return revision
+}
+
+// collectHelmValues writes the user-supplied values of the latest revision of each Helm
+// release in the namespace to helm-values-<release>.yaml, masking credential-like keys
+func (c *Collector) collectHelmValues(namespace, logDir string, scriptLog io.Writer) {
+	console.Printf("  📊 Collecting Helm release values in %s...\n", namespace)
+	fmt.Fprintf(scriptLog, "Collecting Helm release values in %s...\n", namespace)
+
+	c.runHelmActions(namespace, logDir, scriptLog, "Helm release values", c.helmValuesActions)
+}
+
+// helmValuesActions returns an action writing the values of each Helm release in the namespace
+func (c *Collector) helmValuesActions(namespace string) ([]collectAction, error) {
+	releases, err := c.latestHelmReleases(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []collectAction
+	for i := range releases {
+		secret := &releases[i]
+		name := secret.Labels["name"]
+		actions = append(actions, collectAction{"Values of Helm release " + name, fmt.Sprintf("helm-values-%s.yaml", name), func() (string, error) {
+			release, err := decodeHelmRelease(secret)
+			if err != nil {
+				return "", err
+			}
+			output, err := yaml.Marshal(maskSensitiveValues(release.Config))
+			if err != nil {
+				return "", err
+			}
+			header := fmt.Sprintf("# User-supplied values of Helm release %s/%s, revision %d (%s)\n", namespace, name, release.Version, release.Chart.Metadata.Version)
+			return header + c.redact(string(output)), nil
+		}})
+	}
+	return actions, nil
+}
+
+// collectHelmHistory writes every revision of each Helm release in the namespace, with its
+// status, deploy time and chart version, to helm-history-<release>.txt
+func (c *Collector) collectHelmHistory(namespace, logDir string, scriptLog io.Writer) {
+	console.Printf("  📊 Collecting Helm release history in %s...\n", namespace)
+	fmt.Fprintf(scriptLog, "Collecting Helm release history in %s...\n", namespace)
+
+	c.runHelmActions(namespace, logDir, scriptLog, "Helm release history", c.helmHistoryActions)
+}
+
+// helmHistoryActions returns an action writing the history of each Helm release in the namespace
+func (c *Collector) helmHistoryActions(namespace string) ([]collectAction, error) {
+	names, history, err := c.helmReleaseHistory(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []collectAction
+	for _, name := range names {
+		output := formatHelmHistory(namespace, name, history[name])
+		actions = append(actions, collectAction{fmt.Sprintf("History of Helm release %s (%d revisions)", name, len(history[name])), fmt.Sprintf("helm-history-%s.txt", name), func() (string, error) {
+			return output, nil
+		}})
+	}
+	return actions, nil
+}
+
+// runHelmActions runs the per-release actions built from the Helm release secrets. When the
+// secrets cannot be listed, the whole step is recorded and retried.
+func (c *Collector) runHelmActions(namespace, logDir string, scriptLog io.Writer, name string, build func(namespace string) ([]collectAction, error)) {
+	actions, err := build(namespace)
+	if err != nil {
+		c.failStep(namespace, "helm", name, scriptLog, err, func() error {
+			actions, err := build(namespace)
+			if err != nil {
+				return err
+			}
+			return writeActionsOutput(actions, logDir)
+		})
+		return
+	}
+	if len(actions) == 0 {
+		console.Printf("    ⏭️  No Helm releases found in %s\n", namespace)
+		fmt.Fprintf(scriptLog, "  No Helm releases found in %s\n", namespace)
+		return
+	}
+
+	for _, action := range actions {
+		c.runAction(namespace, logDir, scriptLog, action)
+	}
+}
+
+// formatHelmHistory lists the revisions of a release like 'helm history'. Revisions whose
+// release data cannot be decoded fall back to the secret labels and creation time.
+func formatHelmHistory(namespace, name string, revisions []corev1.Secret) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Revision history of Helm release %s/%s\n\n", namespace, name))
+	output.WriteString("REVISION\tUPDATED\tSTATUS\tCHART\tAPP VERSION\tDESCRIPTION\n")
+	for i := range revisions {
+		secret := &revisions[i]
+		updated := secret.CreationTimestamp.UTC().Format(time.RFC3339)
+		status := valueOrNone(secret.Labels["status"])
+		chart, appVersion, description := "unknown", "unknown", "<none>"
+
+		if release, err := decodeHelmRelease(secret); err == nil {
+			if deployed, err := time.Parse(time.RFC3339Nano, release.Info.LastDeployed); err == nil {
+				updated = deployed.UTC().Format(time.RFC3339)
+			}
+			if release.Info.Status != "" {
+				status = release.Info.Status
+			}
+			chart = fmt.Sprintf("%s-%s", release.Chart.Metadata.Name, release.Chart.Metadata.Version)
+			appVersion = valueOrNone(release.Chart.Metadata.AppVersion)
+			description = valueOrNone(release.Info.Description)
+		} else {
+			description = fmt.Sprintf("<undecodable: %v>", err)
+		}
+
+		output.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\t%s\t%s\n",
+			helmRevision(*secret), updated, status, chart, appVersion, description))
+	}
+	return output.String()
+}
+
+// maskSensitiveValues replaces the scalar values of credential-like keys, recursing into
+// nested maps and lists
+func maskSensitiveValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return map[string]interface{}{}
+	}
+	for key, value := range values {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			maskSensitiveValues(typed)
+		case []interface{}:
+			for _, item := range typed {
+				if nested, ok := item.(map[string]interface{}); ok {
+					maskSensitiveValues(nested)
+				}
+			}
+		case nil:
+		default:
+			if sensitiveValueKey.MatchString(key) && value != "" {
+				values[key] = redactedPlaceholder
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,251 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// registryProbeTimeout bounds each --probe-registries request
+const registryProbeTimeout = 5 * time.Second
+
+// imagePullReasons are the waiting reasons of containers whose image cannot be pulled
+var imagePullReasons = map[string]bool{
+	"ErrImagePull":     true,
+	"ImagePullBackOff": true,
+	"InvalidImageName": true,
+}
+
+// collectImagePullInfo writes image-pull.txt: the registries the namespace pods pull from,
+// the pull secrets referenced by pods and service accounts with the registries they
+// cover, and the containers failing to pull their image
+func (c *Collector) collectImagePullInfo(namespace string, listPods func() ([]corev1.Pod, error), logDir string, scriptLog io.Writer) {
+	console.Printf("  📊 Collecting image pull secrets and registries...\n")
+	fmt.Fprintf(scriptLog, "Collecting image pull secrets and registries...\n")
+
+	c.runAction(namespace, logDir, scriptLog, collectAction{"Image pull information", "image-pull.txt", func() (string, error) {
+		return c.getImagePullSummary(namespace, listPods)
+	}})
+}
+
+// pullSecretInfo is an image pull secret and what references it
+type pullSecretInfo struct {
+	referencedBy []string
+	status       string
+	registries   []string
+}
+
+// getImagePullSummary builds the image-pull.txt content for a namespace from its
+// already listed pods
+func (c *Collector) getImagePullSummary(namespace string, listPods func() ([]corev1.Pod, error)) (string, error) {
+	pods, err := listPods()
+	if err != nil {
+		return "", err
+	}
+	serviceAccounts, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	// Pull secrets, by the objects referencing them
+	secrets := map[string]*pullSecretInfo{}
+	reference := func(secret, by string) {
+		if secrets[secret] == nil {
+			secrets[secret] = &pullSecretInfo{}
+		}
+		secrets[secret].referencedBy = append(secrets[secret].referencedBy, by)
+	}
+	for _, serviceAccount := range serviceAccounts.Items {
+		for _, secret := range serviceAccount.ImagePullSecrets {
+			reference(secret.Name, "serviceaccount/"+serviceAccount.Name)
+		}
+	}
+
+	// Registries, by the images pulled from them
+	images := map[string]map[string]bool{}
+	var failures []string
+	for i := range pods {
+		pod := &pods[i]
+		for _, secret := range pod.Spec.ImagePullSecrets {
+			reference(secret.Name, "pod/"+pod.Name)
+		}
+		for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			registry := imageRegistry(container.Image)
+			if images[registry] == nil {
+				images[registry] = map[string]bool{}
+			}
+			images[registry][container.Image] = true
+		}
+		for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			if waiting := status.State.Waiting; waiting != nil && imagePullReasons[waiting.Reason] {
+				failures = append(failures, fmt.Sprintf("%s/%s\t%s\t%s\t%s", pod.Name, status.Name, status.Image, waiting.Reason, waiting.Message))
+			}
+		}
+	}
+
+	covered := map[string][]string{}
+	for name, info := range secrets {
+		info.status, info.registries = c.inspectPullSecret(namespace, name)
+		for _, registry := range info.registries {
+			covered[registry] = append(covered[registry], name)
+		}
+	}
+	for _, names := range covered {
+		sort.Strings(names)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Image pull information for namespace %s\n\n", namespace))
+
+	output.WriteString("== Registries ==\n")
+	if c.opts.ProbeRegistries {
+		output.WriteString("# Reachability is probed from the machine running nmcrun, not from the nodes\n")
+	}
+	output.WriteString("REGISTRY\tIMAGES\tPULL SECRETS\tREACHABLE\n")
+	var registries []string
+	for registry := range images {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	// One client probes every registry; its connections are closed once done
+	client := &http.Client{Timeout: registryProbeTimeout, Transport: http.DefaultTransport.(*http.Transport).Clone()}
+	defer client.CloseIdleConnections()
+	for _, registry := range registries {
+		reachable := "not probed (--probe-registries)"
+		if c.opts.ProbeRegistries {
+			reachable = probeRegistry(client, registry)
+		}
+		output.WriteString(fmt.Sprintf("%s\t%d\t%s\t%s\n", registry, len(images[registry]), valueOrNone(strings.Join(covered[registry], ",")), reachable))
+	}
+
+	output.WriteString("\n== Pull secrets ==\n")
+	output.WriteString("SECRET\tSTATUS\tREGISTRIES\tREFERENCED BY\n")
+	var secretNames []string
+	for name := range secrets {
+		secretNames = append(secretNames, name)
+	}
+	sort.Strings(secretNames)
+	for _, name := range secretNames {
+		info := secrets[name]
+		output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\n", name, info.status, valueOrNone(strings.Join(info.registries, ",")), summarizeReferences(info.referencedBy)))
+	}
+	if len(secrets) == 0 {
+		output.WriteString("No pod or service account references an image pull secret\n")
+	}
+
+	output.WriteString("\n== Image pull failures ==\n")
+	if len(failures) == 0 {
+		output.WriteString("No container is failing to pull its image\n")
+	} else {
+		output.WriteString("CONTAINER\tIMAGE\tREASON\tMESSAGE\n")
+		for _, failure := range failures {
+			output.WriteString(failure + "\n")
+		}
+	}
+	return c.redact(output.String()), nil
+}
+
+// inspectPullSecret returns the status of a pull secret and the registries it holds
+// credentials for. Only the registry names are read, never the credentials.
+func (c *Collector) inspectPullSecret(namespace, name string) (string, []string) {
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "MISSING", nil
+	}
+	if err != nil {
+		return fmt.Sprintf("unreadable: %v", err), nil
+	}
+
+	var auths map[string]json.RawMessage
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		var config struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return fmt.Sprintf("invalid %s: %v", corev1.DockerConfigJsonKey, err), nil
+		}
+		auths = config.Auths
+	case corev1.SecretTypeDockercfg:
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &auths); err != nil {
+			return fmt.Sprintf("invalid %s: %v", corev1.DockerConfigKey, err), nil
+		}
+	default:
+		return fmt.Sprintf("WRONG TYPE %s", secret.Type), nil
+	}
+
+	var registries []string
+	for server := range auths {
+		registries = append(registries, normalizeRegistry(server))
+	}
+	sort.Strings(registries)
+	return "ok", registries
+}
+
+// imageRegistry returns the registry host of an image reference; references without one
+// are pulled from Docker Hub
+func imageRegistry(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return normalizeRegistry(first)
+	}
+	return "docker.io"
+}
+
+// normalizeRegistry reduces a docker config server entry such as https://index.docker.io/v1/
+// to the registry host used in image references
+func normalizeRegistry(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server, _, _ = strings.Cut(server, "/")
+	switch server {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return server
+}
+
+// probeRegistry checks that the registry API answers; 401 is expected without credentials
+func probeRegistry(client *http.Client, registry string) string {
+	host := registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	resp, err := client.Get("https://" + host + "/v2/")
+	if err != nil {
+		return fmt.Sprintf("UNREACHABLE: %v", err)
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusUnauthorized:
+		return "yes"
+	}
+	return fmt.Sprintf("HTTP %d", resp.StatusCode)
+}
+
+// summarizeReferences lists the referencing objects, counting pods instead of listing them
+func summarizeReferences(references []string) string {
+	var listed []string
+	pods := 0
+	for _, reference := range references {
+		if strings.HasPrefix(reference, "pod/") {
+			pods++
+			continue
+		}
+		listed = append(listed, reference)
+	}
+	if pods > 0 {
+		listed = append(listed, fmt.Sprintf("%d pod(s)", pods))
+	}
+	return strings.Join(listed, ",")
+}
@@ -0,0 +1,389 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxIncidentEvents bounds the notable events listed in INCIDENT.md
+const maxIncidentEvents = 50
+
+// waitingReasons are container waiting reasons that mark a pod as affected
+var waitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// affectedPod is a pod that restarted, failed or emitted warnings within the incident window
+type affectedPod struct {
+	namespace string
+	name      string
+	reasons   []string
+}
+
+// CollectIncident collects pod logs, events and a metrics snapshot of the configured
+// namespaces, all bounded by the same window (--since or --since-time), into one bundle
+// with an INCIDENT.md summary of the affected pods and notable events
+func (c *Collector) CollectIncident() error {
+	var windowStart time.Time
+	switch {
+	case c.opts.Since != nil:
+		windowStart = c.startTime.Add(-c.opts.Since.Duration)
+	case c.opts.SinceTime != nil:
+		windowStart = c.opts.SinceTime.Time
+	default:
+		return fmt.Errorf("an incident window is required (--since or --since-time)")
+	}
+	windowEnd := c.startTime
+
+	// Every log request and event filter uses the same absolute start
+	c.opts.Since = nil
+	c.opts.SinceTime = &metav1.Time{Time: windowStart}
+	c.opts.EventsSince = nil
+
+	console.Printf("🚨 Starting incident collection for %s to %s...\n",
+		windowStart.Format(time.RFC3339), windowEnd.Format(time.RFC3339))
+
+	if err := c.testClusterConnection(); err != nil {
+		return fmt.Errorf("cannot connect to Kubernetes cluster: %w", err)
+	}
+	clusterURL, cpURL, _ := c.extractClusterInfo()
+
+	namespaces, err := c.namespacesToCollect()
+	if err != nil {
+		return err
+	}
+
+	bundleName := fmt.Sprintf("%sincident-%s-%s", c.opts.OutputPrefix, c.cleanControlPlaneName(cpURL), c.timestamp)
+	bundleDir := fmt.Sprintf("./%s", bundleName)
+	archiveName := bundleName + c.archiveExtension()
+	if err := os.MkdirAll(bundleDir, 0755); err != nil {
+		return fmt.Errorf("failed to create incident directory: %w", err)
+	}
+
+	scriptLogFile, err := os.Create(filepath.Join(bundleDir, "script.log"))
+	if err != nil {
+		return fmt.Errorf("failed to create script log: %w", err)
+	}
+	defer scriptLogFile.Close()
+	scriptLog := io.Writer(scriptLogFile)
+	fmt.Fprintf(scriptLog, "=== Incident Collection Started at %s ===\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(scriptLog, "Window: %s to %s\n", windowStart.Format(time.RFC3339), windowEnd.Format(time.RFC3339))
+	c.commands.take()
+
+	var affected []affectedPod
+	var notable []corev1.Event
+	var collected []string
+	for _, namespace := range namespaces {
+		console.Printf("\n🔍 Processing namespace: %s\n", namespace)
+		fmt.Fprintf(scriptLog, "\n=== Namespace %s ===\n", namespace)
+
+		exists, err := c.namespaceExists(namespace)
+		if err != nil || !exists {
+			console.Printf("  ❌ Namespace '%s' is not accessible. Skipping.\n", namespace)
+			fmt.Fprintf(scriptLog, "  Warning: Namespace %s is not accessible (exists=%v, err=%v)\n", namespace, exists, err)
+			continue
+		}
+		collected = append(collected, namespace)
+
+		events, err := c.collectIncidentEvents(namespace, bundleDir, scriptLog)
+		if err != nil {
+			console.Printf("  ⚠️  Warning: Failed to collect events: %v\n", err)
+			fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to collect events: %v\n", err)
+		}
+		for _, event := range events {
+			if event.Type == corev1.EventTypeWarning {
+				notable = append(notable, event)
+			}
+		}
+
+		pods, err := c.listPods(namespace, metav1.ListOptions{})
+		if err != nil {
+			console.Printf("  ⚠️  Warning: Failed to list pods: %v\n", err)
+			fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to list pods: %v\n", err)
+			continue
+		}
+		affected = append(affected, incidentAffectedPods(pods, events, windowStart)...)
+		c.collectIncidentLogs(namespace, pods, windowStart, filepath.Join(bundleDir, "logs", namespace), scriptLog)
+	}
+
+	console.Println("\n📈 Collecting metrics snapshot...")
+	if output, err := c.getMetricsSnapshot(collected); err != nil {
+		console.Printf("  ⚠️  Warning: Failed to collect metrics: %v\n", err)
+		fmt.Fprintf(scriptLog, "⚠ Warning: Failed to collect metrics: %v\n", err)
+	} else if err := os.WriteFile(filepath.Join(bundleDir, "metrics.txt"), []byte(output), 0644); err != nil {
+		console.Printf("  ⚠️  Warning: Failed to write metrics.txt: %v\n", err)
+	} else {
+		console.Println("  ✅ Metrics snapshot saved")
+	}
+
+	report := incidentReport(windowStart, windowEnd, clusterURL, collected, affected, notable)
+	if err := os.WriteFile(filepath.Join(bundleDir, "INCIDENT.md"), []byte(c.redact(report)), 0644); err != nil {
+		return fmt.Errorf("failed to write INCIDENT.md: %w", err)
+	}
+	console.Printf("📝 INCIDENT.md written: %d affected pods, %d warning events\n", len(affected), len(notable))
+
+	if err := c.writeCommands(filepath.Join(bundleDir, "commands.txt")); err != nil {
+		console.Printf("⚠️  Warning: Failed to write commands: %v\n", err)
+	}
+
+	console.Println("\n📦 === Creating Archive ===")
+	entries, err := c.createArchive(bundleDir, archiveName, scriptLog)
+	if err != nil {
+		os.Remove(archiveName)
+		return fmt.Errorf("failed to create archive, keeping %s: %w", bundleDir, err)
+	}
+	if err := c.verifyArchive(archiveName, entries); err != nil {
+		return fmt.Errorf("archive verification failed, keeping %s: %w", bundleDir, err)
+	}
+
+	if c.opts.KeepDir {
+		console.Printf("📁 Keeping collection directory %s (--keep-dir)\n", bundleDir)
+	} else if err := os.RemoveAll(bundleDir); err != nil {
+		console.Printf("⚠️  Warning: Failed to clean up %s: %v\n", bundleDir, err)
+	}
+
+	console.Printf("\n✅ Incident collection completed!\n")
+	console.Printf("📦 Archive created: %s\n", archiveName)
+	return nil
+}
+
+// collectIncidentEvents writes the namespace events within the window to events/<namespace>.txt
+func (c *Collector) collectIncidentEvents(namespace, bundleDir string, scriptLog io.Writer) ([]corev1.Event, error) {
+	console.Printf("  📄 Collecting events...\n")
+	events, err := c.clientset.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	items := c.filterEvents(events.Items)
+
+	eventsDir := filepath.Join(bundleDir, "events")
+	if err := os.MkdirAll(eventsDir, 0755); err != nil {
+		return items, err
+	}
+	if err := os.WriteFile(filepath.Join(eventsDir, namespace+".txt"), []byte(c.redact(formatEvents(items))), 0644); err != nil {
+		return items, err
+	}
+
+	console.Printf("    ✅ %d events saved\n", len(items))
+	fmt.Fprintf(scriptLog, "  ✓ %d events saved\n", len(items))
+	return items, nil
+}
+
+// collectIncidentLogs writes the window's logs of every container of the pods, plus the
+// previous logs of containers that restarted within the window
+func (c *Collector) collectIncidentLogs(namespace string, pods []corev1.Pod, windowStart time.Time, logsDir string, scriptLog io.Writer) {
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		console.Printf("  ⚠️  Warning: Failed to create %s: %v\n", logsDir, err)
+		fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to create %s: %v\n", logsDir, err)
+		return
+	}
+
+	console.Printf("  📋 Collecting logs of %d pods...\n", len(pods))
+	saved := 0
+	for _, pod := range pods {
+		var containers []string
+		for _, container := range pod.Spec.Containers {
+			containers = append(containers, container.Name)
+		}
+		containers, _ = c.filterContainers(containers)
+
+		for _, container := range containers {
+			logFile := filepath.Join(logsDir, fmt.Sprintf("%s_%s.log", pod.Name, container))
+			if _, err := c.collectContainerLogs(pod.Name, container, namespace, logFile, false); err != nil {
+				fmt.Fprintf(scriptLog, "  ⚠ Warning: Failed to collect logs for %s/%s: %v\n", pod.Name, container, err)
+				continue
+			}
+			saved++
+
+			if restartedSince(pod.Status.ContainerStatuses, container, windowStart) {
+				c.collectPreviousLogs(pod.Name, container, namespace, filepath.Join(logsDir, fmt.Sprintf("%s_%s_previous.log", pod.Name, container)), scriptLog)
+			}
+		}
+	}
+
+	console.Printf("    ✅ Logs saved for %d containers\n", saved)
+	fmt.Fprintf(scriptLog, "  ✓ Logs saved for %d containers\n", saved)
+}
+
+// restartedSince reports whether the named container's last instance terminated after start
+func restartedSince(statuses []corev1.ContainerStatus, container string, start time.Time) bool {
+	for _, status := range statuses {
+		if status.Name == container && status.LastTerminationState.Terminated != nil {
+			return !status.LastTerminationState.Terminated.FinishedAt.Time.Before(start)
+		}
+	}
+	return false
+}
+
+// incidentAffectedPods returns the pods that restarted, are failing, or have warning
+// events within the window, with the reasons they were selected
+func incidentAffectedPods(pods []corev1.Pod, events []corev1.Event, windowStart time.Time) []affectedPod {
+	warnings := map[string]map[string]int{}
+	for _, event := range events {
+		if event.Type != corev1.EventTypeWarning || event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		if warnings[event.InvolvedObject.Name] == nil {
+			warnings[event.InvolvedObject.Name] = map[string]int{}
+		}
+		warnings[event.InvolvedObject.Name][event.Reason]++
+	}
+
+	var affected []affectedPod
+	for _, pod := range pods {
+		var reasons []string
+		if pod.Status.Phase == corev1.PodFailed {
+			reasons = append(reasons, fmt.Sprintf("pod Failed (%s)", valueOrNone(pod.Status.Reason)))
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if terminated := status.LastTerminationState.Terminated; terminated != nil && !terminated.FinishedAt.Time.Before(windowStart) {
+				reasons = append(reasons, fmt.Sprintf("container %s restarted at %s (%s, exit code %d, %d restarts total)",
+					status.Name, terminated.FinishedAt.UTC().Format(time.RFC3339), valueOrNone(terminated.Reason), terminated.ExitCode, status.RestartCount))
+			}
+			if waiting := status.State.Waiting; waiting != nil && waitingReasons[waiting.Reason] {
+				reasons = append(reasons, fmt.Sprintf("container %s is waiting: %s", status.Name, waiting.Reason))
+			}
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 && !terminated.FinishedAt.Time.Before(windowStart) {
+				reasons = append(reasons, fmt.Sprintf("container %s exited with code %d (%s)", status.Name, terminated.ExitCode, valueOrNone(terminated.Reason)))
+			}
+		}
+
+		if counts := warnings[pod.Name]; len(counts) > 0 {
+			var parts []string
+			for reason, count := range counts {
+				parts = append(parts, fmt.Sprintf("%s x%d", reason, count))
+			}
+			sort.Strings(parts)
+			reasons = append(reasons, "warning events: "+strings.Join(parts, ", "))
+		}
+
+		if len(reasons) > 0 {
+			affected = append(affected, affectedPod{namespace: pod.Namespace, name: pod.Name, reasons: reasons})
+		}
+	}
+	return affected
+}
+
+// incidentReport renders INCIDENT.md
+func incidentReport(windowStart, windowEnd time.Time, clusterURL string, namespaces []string, affected []affectedPod, notable []corev1.Event) string {
+	var output strings.Builder
+	output.WriteString("# Incident bundle\n\n")
+	output.WriteString(fmt.Sprintf("- **Window:** %s to %s (%s)\n", windowStart.UTC().Format(time.RFC3339), windowEnd.UTC().Format(time.RFC3339), windowEnd.Sub(windowStart).Round(time.Second)))
+	output.WriteString(fmt.Sprintf("- **Cluster URL:** %s\n", clusterURL))
+	output.WriteString(fmt.Sprintf("- **Namespaces:** %s\n\n", strings.Join(namespaces, ", ")))
+
+	output.WriteString(fmt.Sprintf("## Affected pods (%d)\n\n", len(affected)))
+	if len(affected) == 0 {
+		output.WriteString("No pod restarted, failed or emitted warning events within the window.\n")
+	}
+	for _, pod := range affected {
+		output.WriteString(fmt.Sprintf("- `%s/%s`\n", pod.namespace, pod.name))
+		for _, reason := range pod.reasons {
+			output.WriteString(fmt.Sprintf("  - %s\n", reason))
+		}
+	}
+
+	sort.Slice(notable, func(i, j int) bool {
+		return eventTime(notable[i]).After(eventTime(notable[j]))
+	})
+	output.WriteString(fmt.Sprintf("\n## Notable events (%d warnings, newest first)\n\n", len(notable)))
+	if len(notable) > maxIncidentEvents {
+		output.WriteString(fmt.Sprintf("Showing the newest %d; see `events/` for all events.\n\n", maxIncidentEvents))
+		notable = notable[:maxIncidentEvents]
+	}
+	if len(notable) == 0 {
+		output.WriteString("No warning events within the window.\n")
+	}
+	for _, event := range notable {
+		output.WriteString(fmt.Sprintf("- %s `%s/%s` %s: %s (x%d)\n",
+			eventTime(event).UTC().Format(time.RFC3339), event.InvolvedObject.Namespace, event.InvolvedObject.Name,
+			event.Reason, strings.TrimSpace(event.Message), event.Count))
+	}
+
+	output.WriteString("\n## Contents\n\n")
+	output.WriteString("- `logs/<namespace>/`: container logs within the window, and `_previous.log` for containers that restarted in it\n")
+	output.WriteString("- `events/<namespace>.txt`: all events within the window\n")
+	output.WriteString("- `metrics.txt`: node and pod resource usage at collection time (metrics-server)\n")
+	output.WriteString("- `commands.txt`: kubectl equivalents of the requests made\n")
+	return output.String()
+}
+
+// getMetricsSnapshot reports the current node and pod resource usage from metrics-server
+func (c *Collector) getMetricsSnapshot(namespaces []string) (string, error) {
+	nodesGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+	podsGVR := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	if !c.servesResource(nodesGVR) {
+		return "", fmt.Errorf("the metrics.k8s.io API is not available (is metrics-server installed?)")
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Resource usage snapshot at %s\n\n", time.Now().UTC().Format(time.RFC3339)))
+
+	nodes, err := c.dynamicClient.Resource(nodesGVR).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list node metrics: %w", err)
+	}
+	output.WriteString("NODE\tCPU\tMEMORY\n")
+	for _, node := range nodes.Items {
+		usage, _, _ := unstructured.NestedStringMap(node.Object, "usage")
+		cpu, memory := formatUsage(usage)
+		output.WriteString(fmt.Sprintf("%s\t%s\t%s\n", node.GetName(), cpu, memory))
+	}
+
+	for _, namespace := range namespaces {
+		pods, err := c.dynamicClient.Resource(podsGVR).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			output.WriteString(fmt.Sprintf("\n# Namespace %s\nError: %v\n", namespace, err))
+			continue
+		}
+		output.WriteString(fmt.Sprintf("\n# Namespace %s\nPOD\tCONTAINER\tCPU\tMEMORY\n", namespace))
+		for _, pod := range pods.Items {
+			containers, _, _ := unstructured.NestedSlice(pod.Object, "containers")
+			for _, item := range containers {
+				container, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _, _ := unstructured.NestedString(container, "name")
+				usage, _, _ := unstructured.NestedStringMap(container, "usage")
+				cpu, memory := formatUsage(usage)
+				output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\n", pod.GetName(), name, cpu, memory))
+			}
+		}
+	}
+
+	return output.String(), nil
+}
+
+// formatUsage formats a metrics usage map as millicores and MiB, like kubectl top
+func formatUsage(usage map[string]string) (string, string) {
+	cpu, memory := "<unknown>", "<unknown>"
+	if quantity, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+		cpu = fmt.Sprintf("%dm", quantity.MilliValue())
+	}
+	if quantity, err := resource.ParseQuantity(usage["memory"]); err == nil {
+		memory = fmt.Sprintf("%dMi", quantity.Value()/(1024*1024))
+	}
+	return cpu, memory
+}
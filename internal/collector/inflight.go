@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// inflightLimiter is an http.RoundTripper bounding the number of in-flight API
+// requests across every client and worker pool sharing it. A request holds its slot
+// until the response body is closed, so streamed pod logs count while they are read.
+type inflightLimiter struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+// inflightWrapper returns a transport wrapper whose transports share limit in-flight slots
+func inflightWrapper(limit int) func(http.RoundTripper) http.RoundTripper {
+	slots := make(chan struct{}, limit)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &inflightLimiter{next: next, slots: slots}
+	}
+}
+
+func (l *inflightLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case l.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := l.next.RoundTrip(req)
+	if err != nil {
+		<-l.slots
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-l.slots }}
+	return resp, nil
+}
+
+// releasingBody releases an in-flight slot once when the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,316 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"nmcrun/internal/console"
+)
+
+// inspectNode is a file or directory of an inspected archive
+type inspectNode struct {
+	size     int64
+	children map[string]*inspectNode
+}
+
+// Inspect summarizes an existing archive without extracting it: a tree of its files with
+// their sizes, the total size, the number of pods and containers with logs, and the
+// contents of manifest.json and errors.txt if the archive has them. The whole archive is
+// read, so a truncated or corrupt archive is reported as an error.
+func Inspect(archiveName string) error {
+	format, err := archiveFormatOf(archiveName)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(archiveName)
+	if err != nil {
+		return err
+	}
+
+	archiveReader, err := openArchive(archiveName, format)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer archiveReader.Close()
+
+	root := &inspectNode{children: map[string]*inspectNode{}}
+	notes := map[string]string{}
+	// The SHA-256 of every file, and the checksums listed by the SHA256SUMS entries
+	hashes := map[string]string{}
+	listed := map[string]string{}
+	checksumFiles := 0
+	files := 0
+	var readErr error
+	for {
+		entry, err := archiveReader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			readErr = fmt.Errorf("corrupt archive after %d files: %w", files, err)
+			break
+		}
+
+		isDir := strings.HasSuffix(entry.name, "/")
+		node := root.add(strings.TrimSuffix(entry.name, "/"), isDir)
+		if isDir {
+			continue
+		}
+
+		// manifest.json, errors.txt and SHA256SUMS are kept, everything else is only read through
+		var content strings.Builder
+		var w io.Writer = io.Discard
+		base := path.Base(entry.name)
+		if base == "manifest.json" || base == "errors.txt" || base == checksumsFileName {
+			w = &content
+		}
+		hash := sha256.New()
+		size, err := io.Copy(io.MultiWriter(w, hash), archiveReader)
+		if err != nil {
+			readErr = fmt.Errorf("failed to read %s from archive: %w", entry.name, err)
+			break
+		}
+		node.size = size
+		hashes[entry.name] = hex.EncodeToString(hash.Sum(nil))
+		files++
+
+		switch {
+		case base == checksumsFileName:
+			sums, err := parseChecksums(strings.NewReader(content.String()), path.Dir(entry.name))
+			if err != nil {
+				readErr = fmt.Errorf("failed to read %s: %w", entry.name, err)
+			}
+			for name, sum := range sums {
+				listed[name] = sum
+			}
+			checksumFiles++
+		case w != io.Discard:
+			notes[entry.name] = content.String()
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	console.Printf("📦 %s (%s, %s)\n", archiveName, format, formatSize(info.Size()))
+	root.print("")
+
+	pods, containers := countLogs(root)
+	console.Printf("\n%d files, %s uncompressed\n", files, formatSize(root.total()))
+	console.Printf("Logs of %d container(s) in %d pod(s)\n", containers, pods)
+
+	var noteNames []string
+	for name := range notes {
+		noteNames = append(noteNames, name)
+	}
+	sort.Strings(noteNames)
+	for _, name := range noteNames {
+		console.Printf("\n=== %s ===\n", name)
+		console.Printf("%s", notes[name])
+		if !strings.HasSuffix(notes[name], "\n") {
+			console.Println()
+		}
+	}
+
+	if readErr != nil {
+		return readErr
+	}
+	console.Println("\n✅ Archive read completely")
+	return verifyChecksums(archiveName, hashes, listed, checksumFiles)
+}
+
+// verifyChecksums compares the files of an archive with the checksums its SHA256SUMS
+// entries list, and the archive itself with its <archive>.sha256 file when there is one
+func verifyChecksums(archiveName string, hashes, listed map[string]string, checksumFiles int) error {
+	var problems []string
+	if checksumFiles == 0 {
+		console.Printf("ℹ️  No %s in the archive, file checksums not verified\n", checksumsFileName)
+	} else {
+		var names []string
+		for name := range listed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hash, found := hashes[name]
+			switch {
+			case !found:
+				problems = append(problems, fmt.Sprintf("%s is listed in %s but missing", name, checksumsFileName))
+			case hash != listed[name]:
+				problems = append(problems, fmt.Sprintf("%s does not match its checksum", name))
+			}
+		}
+		if len(problems) == 0 {
+			console.Printf("✅ %d file checksum(s) verified against %d %s\n", len(listed), checksumFiles, checksumsFileName)
+		}
+	}
+
+	if sidecar, err := os.ReadFile(archiveName + ".sha256"); err == nil {
+		sum, _, _ := strings.Cut(strings.TrimSpace(string(sidecar)), " ")
+		hash, err := fileSHA256(archiveName)
+		switch {
+		case err != nil:
+			return err
+		case hash != sum:
+			problems = append(problems, fmt.Sprintf("%s does not match %s.sha256", archiveName, archiveName))
+		default:
+			console.Printf("✅ Archive checksum verified against %s.sha256\n", archiveName)
+		}
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			console.Printf("❌ %s\n", problem)
+		}
+		return fmt.Errorf("archive integrity check failed: %d problem(s)", len(problems))
+	}
+	return nil
+}
+
+// fileSHA256 returns the hex SHA-256 of a file's content
+func fileSHA256(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// archiveFormatOf returns the archive format matching the file extension
+func archiveFormatOf(archiveName string) (string, error) {
+	if strings.HasSuffix(archiveName, ".tgz") {
+		return FormatTarGz, nil
+	}
+	for _, format := range ArchiveFormats {
+		if strings.HasSuffix(archiveName, "."+format) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown archive format of %s (expected .%s)", archiveName, strings.Join(ArchiveFormats, ", ."))
+}
+
+// add returns the node of a slash-separated path, creating it and its parent directories
+func (n *inspectNode) add(name string, isDir bool) *inspectNode {
+	node := n
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		child := node.children[part]
+		if child == nil {
+			child = &inspectNode{}
+			node.children[part] = child
+		}
+		if child.children == nil && (isDir || i < len(parts)-1) {
+			child.children = map[string]*inspectNode{}
+		}
+		node = child
+	}
+	return node
+}
+
+// total is the size of a file, or of all files below a directory
+func (n *inspectNode) total() int64 {
+	total := n.size
+	for _, child := range n.children {
+		total += child.total()
+	}
+	return total
+}
+
+// print prints the children of a directory, directories first, sorted by name
+func (n *inspectNode) print(indent string) {
+	var names []string
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		iDir, jDir := n.children[names[i]].children != nil, n.children[names[j]].children != nil
+		if iDir != jDir {
+			return iDir
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		child := n.children[name]
+		if child.children != nil {
+			console.Printf("%s%s/ (%s)\n", indent, name, formatSize(child.total()))
+			child.print(indent + "  ")
+			continue
+		}
+		console.Printf("%s%s %s\n", indent, name, formatSize(child.size))
+	}
+}
+
+// countLogs counts the pods and containers with container logs, the <pod>_<container>...log
+// files in logs directories
+func countLogs(root *inspectNode) (int, int) {
+	pods, containers := map[string]bool{}, map[string]bool{}
+	var walk func(n *inspectNode, dir string)
+	walk = func(n *inspectNode, dir string) {
+		for name, child := range n.children {
+			if child.children != nil {
+				walk(child, dir+name+"/")
+				continue
+			}
+			if !strings.Contains("/"+dir, "/logs/") {
+				continue
+			}
+			if pod, container, ok := parseLogFileName(name); ok {
+				pods[dir+pod] = true
+				containers[dir+pod+"/"+container] = true
+			}
+		}
+	}
+	walk(root, "")
+	return len(pods), len(containers)
+}
+
+// parseLogFileName returns the pod and container of a container log file name
+func parseLogFileName(name string) (string, string, bool) {
+	name = strings.TrimSuffix(name, ".gz")
+	switch {
+	case strings.HasSuffix(name, ".log"):
+		name = strings.TrimSuffix(name, ".log")
+	case strings.HasSuffix(name, ".json"):
+		name = strings.TrimSuffix(name, ".json")
+	default:
+		return "", "", false
+	}
+	name = strings.TrimSuffix(name, ".filtered")
+	for _, suffix := range []string{"_previous", "_restart"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	name = strings.TrimSuffix(name, "_init")
+
+	// Pod and container names cannot contain underscores
+	pod, container, found := strings.Cut(name, "_")
+	if !found || pod == "" || container == "" || strings.Contains(container, "_") {
+		return "", "", false
+	}
+	return pod, container, true
+}
+
+// formatSize formats a number of bytes for display
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.2f GB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.2f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// workloadTypes lists the RunAI workload types with their short aliases, in menu order
+var workloadTypes = []struct {
+	alias    string
+	resource string
+}{
+	{"tw", "trainingworkloads"},
+	{"iw", "interactiveworkloads"},
+	{"infw", "inferenceworkloads"},
+	{"dw", "distributedworkloads"},
+	{"dinfw", "distributedinferenceworkloads"},
+	{"ew", "externalworkloads"},
+}
+
+// SelectWorkload interactively asks for a project, workload type and workload
+// using numbered prompts, and returns them in the form CollectWorkloadInfo expects
+func (c *Collector) SelectWorkload(in io.Reader, out io.Writer) (string, string, string, error) {
+	reader := bufio.NewReader(in)
+
+	projects, err := c.ListProjects()
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to list projects: %w", err)
+	}
+	if len(projects) == 0 {
+		return "", "", "", fmt.Errorf("no RunAI projects found (no namespaces labelled runai/queue)")
+	}
+
+	projectIndex, err := promptChoice(reader, out, "📁 Select a project:", projects)
+	if err != nil {
+		return "", "", "", err
+	}
+	project := projects[projectIndex]
+
+	namespace, err := c.getNamespaceByLabel(fmt.Sprintf("runai/queue=%s", project))
+	if err != nil {
+		return "", "", "", fmt.Errorf("no namespace found for project: %s", project)
+	}
+
+	// Only offer workload types that have workloads in the project
+	var typeLabels []string
+	var typeAliases []string
+	workloadsByType := map[string][]string{}
+	for _, workloadType := range workloadTypes {
+		names, err := c.listResourceNames(namespace, workloadType.resource)
+		if err != nil || len(names) == 0 {
+			continue
+		}
+		workloadsByType[workloadType.alias] = names
+		typeAliases = append(typeAliases, workloadType.alias)
+		typeLabels = append(typeLabels, fmt.Sprintf("%s (%s) - %d workload(s)", workloadType.resource, workloadType.alias, len(names)))
+	}
+	if len(typeAliases) == 0 {
+		return "", "", "", fmt.Errorf("no workloads found in project: %s", project)
+	}
+
+	typeIndex, err := promptChoice(reader, out, "🗂️  Select a workload type:", typeLabels)
+	if err != nil {
+		return "", "", "", err
+	}
+	workloadType := typeAliases[typeIndex]
+
+	workloads := workloadsByType[workloadType]
+	workloadIndex, err := promptChoice(reader, out, "🚀 Select a workload:", workloads)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return project, workloadType, workloads[workloadIndex], nil
+}
+
+// WorkloadTypeAliases returns the short workload type aliases accepted by --type
+func WorkloadTypeAliases() []string {
+	var aliases []string
+	for _, workloadType := range workloadTypes {
+		aliases = append(aliases, workloadType.alias)
+	}
+	return aliases
+}
+
+// ListWorkloads gets the names of workloads of a type in a project
+func (c *Collector) ListWorkloads(project, workloadType string) ([]string, error) {
+	canonicalType := c.getCanonicalWorkloadType(workloadType)
+	if canonicalType == "" {
+		return nil, fmt.Errorf("invalid workload type: %s", workloadType)
+	}
+
+	namespace, err := c.getNamespaceByLabel(fmt.Sprintf("runai/queue=%s", project))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listResourceNames(namespace, canonicalType)
+}
+
+// ListProjects gets the RunAI project names from the runai/queue namespace label
+func (c *Collector) ListProjects() ([]string, error) {
+	namespaces, err := c.clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: "runai/queue",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []string
+	for _, namespace := range namespaces.Items {
+		if project := namespace.Labels["runai/queue"]; project != "" {
+			projects = append(projects, project)
+		}
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// listResourceNames lists the names of a resource type in a namespace using the known GVR fallbacks
+func (c *Collector) listResourceNames(namespace, resource string) ([]string, error) {
+	gvrList, err := c.gvrsFor(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, gvr := range gvrList {
+		list, err := c.resourceClient(gvr, namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.GetName())
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	return nil, lastErr
+}
+
+// promptChoice prints a numbered list and reads the user's selection
+func promptChoice(reader *bufio.Reader, out io.Writer, title string, options []string) (int, error) {
+	fmt.Fprintf(out, "\n%s\n", title)
+	for i, option := range options {
+		fmt.Fprintf(out, "  %d) %s\n", i+1, option)
+	}
+
+	for {
+		fmt.Fprintf(out, "Enter number [1-%d]: ", len(options))
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return 0, fmt.Errorf("no selection made: %w", err)
+		}
+
+		choice, convErr := strconv.Atoi(strings.TrimSpace(line))
+		if convErr == nil && choice >= 1 && choice <= len(options) {
+			return choice - 1, nil
+		}
+		fmt.Fprintf(out, "❌ Invalid selection: %q\n", strings.TrimSpace(line))
+		if err != nil {
+			return 0, fmt.Errorf("no valid selection made: %w", err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Leader election roles of operator pods
+const (
+	roleLeader  = "leader"
+	roleStandby = "standby"
+)
+
+// leaseHolder is the pod holding a RunAI leader-election lease
+type leaseHolder struct {
+	lease string
+	pod   string
+}
+
+// getRunAILeaseHolders reads the runai-* leader-election Leases of a namespace and
+// returns the pods holding them. Holder identities look like "<pod>_<uuid>".
+func (c *Collector) getRunAILeaseHolders(namespace string) ([]leaseHolder, error) {
+	leases, err := c.clientset.CoordinationV1().Leases(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var holders []leaseHolder
+	for _, lease := range leases.Items {
+		if !strings.HasPrefix(lease.Name, "runai") || lease.Spec.HolderIdentity == nil {
+			continue
+		}
+		pod, _, _ := strings.Cut(*lease.Spec.HolderIdentity, "_")
+		if pod == "" {
+			continue
+		}
+		holders = append(holders, leaseHolder{lease: lease.Name, pod: pod})
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i].lease < holders[j].lease })
+	return holders, nil
+}
+
+// leaderRoles marks lease-holding pods as leaders and the other replicas of the same
+// workload (same generated name prefix) as standbys
+func leaderRoles(pods []string, holders []leaseHolder) map[string]string {
+	roles := map[string]string{}
+	for _, holder := range holders {
+		prefix := replicaPrefix(holder.pod)
+		for _, pod := range pods {
+			switch {
+			case pod == holder.pod:
+				roles[pod] = roleLeader
+			case prefix != "" && replicaPrefix(pod) == prefix && roles[pod] != roleLeader:
+				roles[pod] = roleStandby
+			}
+		}
+	}
+	return roles
+}
+
+// replicaPrefix strips the generated replicaset hash and pod suffix from a pod name
+// (runai-operator-7c9f8d6b5-x2k4p -> runai-operator)
+func replicaPrefix(pod string) string {
+	parts := strings.Split(pod, "-")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], "-")
+}
+
+// leadersFirst orders pods so leader-election leaders are collected first
+func leadersFirst(pods []string, roles map[string]string) []string {
+	ordered := append([]string{}, pods...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return roles[ordered[i]] == roleLeader && roles[ordered[j]] != roleLeader
+	})
+	return ordered
+}
+
+// writeLeaderElection writes the lease holders and pod roles to a file
+func writeLeaderElection(path string, holders []leaseHolder, roles map[string]string) error {
+	var output strings.Builder
+	output.WriteString("LEASE\tHOLDER\n")
+	for _, holder := range holders {
+		output.WriteString(fmt.Sprintf("%s\t%s\n", holder.lease, holder.pod))
+	}
+
+	var pods []string
+	for pod := range roles {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+
+	output.WriteString("\nPOD\tROLE\n")
+	for _, pod := range pods {
+		output.WriteString(fmt.Sprintf("%s\t%s\n", pod, roles[pod]))
+	}
+	return os.WriteFile(path, []byte(output.String()), 0644)
+}
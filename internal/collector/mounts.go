@@ -0,0 +1,162 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getWorkloadMounts writes <workload>_<type>_mounts.txt listing every workload pod's volumes
+// and where each container mounts them, with the status of the PersistentVolumeClaims behind
+// them. The pod specs come from the list shared with the Pod YAML step.
+func (c *Collector) getWorkloadMounts(workloadPods func() (*corev1.PodList, error), namespace, workload, typeSafe string) (string, error) {
+	filename := fmt.Sprintf("%s_%s_mounts.txt", workload, typeSafe)
+	console.Printf("  📄 Getting volume mounts...\n")
+
+	pods, err := workloadPods()
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pods found for workload: %s", workload)
+	}
+
+	// Each claim is looked up once even when shared by several pods
+	claims := map[string]string{}
+	claimStatus := func(name string) string {
+		if status, seen := claims[name]; seen {
+			return status
+		}
+		claims[name] = c.describeClaim(namespace, name)
+		return claims[name]
+	}
+
+	var sections []string
+	for i := range pods.Items {
+		sections = append(sections, describePodMounts(&pods.Items[i], claimStatus))
+	}
+
+	output := strings.Join(sections, "\n---\n\n")
+	if err := os.WriteFile(filename, []byte(c.redact(output)), 0644); err != nil {
+		return "", err
+	}
+
+	console.Printf("    ✅ Volume mounts retrieved\n")
+	return filename, nil
+}
+
+// describeClaim summarizes the binding state of a PersistentVolumeClaim
+func (c *Collector) describeClaim(namespace, name string) string {
+	pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+
+	storageClass := "<none>"
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+	capacity := "<none>"
+	if storage, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		capacity = storage.String()
+	}
+	volume := pvc.Spec.VolumeName
+	if volume == "" {
+		volume = "<none>"
+	}
+	return fmt.Sprintf("phase=%s volume=%s capacity=%s storageClass=%s", pvc.Status.Phase, volume, capacity, storageClass)
+}
+
+// describePodMounts renders a pod's volumes and the container mounts referencing them,
+// flagging mounts of undefined volumes and volumes nothing mounts
+func describePodMounts(pod *corev1.Pod, claimStatus func(name string) string) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Pod: %s (node: %s, phase: %s)\n", pod.Name, pod.Spec.NodeName, pod.Status.Phase))
+
+	volumes := map[string]bool{}
+	output.WriteString("Volumes:\n")
+	if len(pod.Spec.Volumes) == 0 {
+		output.WriteString("  <none>\n")
+	}
+	for _, volume := range pod.Spec.Volumes {
+		volumes[volume.Name] = true
+		output.WriteString(fmt.Sprintf("  %s: %s\n", volume.Name, describeVolumeSource(volume.VolumeSource, claimStatus)))
+	}
+
+	mounted := map[string]bool{}
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	output.WriteString("Mounts:\n")
+	for _, container := range containers {
+		output.WriteString(fmt.Sprintf("  %s:\n", container.Name))
+		if len(container.VolumeMounts) == 0 {
+			output.WriteString("    <none>\n")
+		}
+		for _, mount := range container.VolumeMounts {
+			mounted[mount.Name] = true
+			line := fmt.Sprintf("    %s from %s", mount.MountPath, mount.Name)
+			if mount.SubPath != "" {
+				line += fmt.Sprintf(" (subPath %s)", mount.SubPath)
+			}
+			if mount.SubPathExpr != "" {
+				line += fmt.Sprintf(" (subPathExpr %s)", mount.SubPathExpr)
+			}
+			if mount.ReadOnly {
+				line += " (ro)"
+			} else {
+				line += " (rw)"
+			}
+			if !volumes[mount.Name] {
+				line += " ⚠️ volume not defined in pod spec"
+			}
+			output.WriteString(line + "\n")
+		}
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if !mounted[volume.Name] {
+			output.WriteString(fmt.Sprintf("Note: volume %s is not mounted by any container\n", volume.Name))
+		}
+	}
+
+	return output.String()
+}
+
+// describeVolumeSource summarizes where a volume's data comes from
+func describeVolumeSource(source corev1.VolumeSource, claimStatus func(name string) string) string {
+	switch {
+	case source.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("PersistentVolumeClaim %s (%s)", source.PersistentVolumeClaim.ClaimName, claimStatus(source.PersistentVolumeClaim.ClaimName))
+	case source.ConfigMap != nil:
+		return fmt.Sprintf("ConfigMap %s", source.ConfigMap.Name)
+	case source.Secret != nil:
+		return fmt.Sprintf("Secret %s", source.Secret.SecretName)
+	case source.EmptyDir != nil:
+		description := "EmptyDir"
+		if source.EmptyDir.Medium != "" {
+			description += fmt.Sprintf(" (medium %s)", source.EmptyDir.Medium)
+		}
+		if source.EmptyDir.SizeLimit != nil {
+			description += fmt.Sprintf(" (sizeLimit %s)", source.EmptyDir.SizeLimit.String())
+		}
+		return description
+	case source.HostPath != nil:
+		return fmt.Sprintf("HostPath %s", source.HostPath.Path)
+	case source.NFS != nil:
+		return fmt.Sprintf("NFS %s:%s", source.NFS.Server, source.NFS.Path)
+	case source.CSI != nil:
+		return fmt.Sprintf("CSI %s", source.CSI.Driver)
+	case source.Projected != nil:
+		return fmt.Sprintf("Projected (%d sources)", len(source.Projected.Sources))
+	case source.DownwardAPI != nil:
+		return "DownwardAPI"
+	case source.Ephemeral != nil:
+		return "Ephemeral"
+	}
+	return "other"
+}
@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nvidiaPrefix marks the node labels and annotations set by the NVIDIA GPU operator,
+// e.g. the driver and CUDA versions published by GPU feature discovery
+const nvidiaPrefix = "nvidia.com/"
+
+// getNodeRuntime lists the host-level details behind GPU driver and runtime mismatches: each
+// node's kernel, OS, kubelet, kube-proxy and container runtime versions, its architecture,
+// and its nvidia.com/* labels and annotations. Versions differing between nodes are
+// summarized first.
+func (c *Collector) getNodeRuntime() (string, error) {
+	nodes, err := c.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+
+	var output strings.Builder
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tOS/ARCH\tOS-IMAGE\tKERNEL-VERSION\tKUBELET\tKUBE-PROXY\tCONTAINER-RUNTIME")
+
+	// Distinct values of each version across nodes, by the nodes having them
+	versions := map[string]map[string][]string{}
+	record := func(key, value, node string) {
+		if versions[key] == nil {
+			versions[key] = map[string][]string{}
+		}
+		versions[key][value] = append(versions[key][value], node)
+	}
+
+	var details strings.Builder
+	for _, node := range nodes.Items {
+		info := node.Status.NodeInfo
+		fmt.Fprintf(w, "%s\t%s/%s\t%s\t%s\t%s\t%s\t%s\n", node.Name, info.OperatingSystem, info.Architecture,
+			valueOrNone(info.OSImage), valueOrNone(info.KernelVersion), valueOrNone(info.KubeletVersion),
+			valueOrNone(info.KubeProxyVersion), valueOrNone(info.ContainerRuntimeVersion))
+
+		record("kernel", info.KernelVersion, node.Name)
+		record("os image", info.OSImage, node.Name)
+		record("kubelet", info.KubeletVersion, node.Name)
+		record("container runtime", info.ContainerRuntimeVersion, node.Name)
+
+		labels := nvidiaEntries(node.Labels)
+		annotations := nvidiaEntries(node.Annotations)
+		for _, label := range labels {
+			if key, value, _ := strings.Cut(label, "="); strings.Contains(key, "driver") || strings.Contains(key, "cuda") {
+				record(key, value, node.Name)
+			}
+		}
+
+		details.WriteString(fmt.Sprintf("\nnode/%s\n", node.Name))
+		details.WriteString("  labels:\n")
+		writeEntries(&details, labels)
+		details.WriteString("  annotations:\n")
+		writeEntries(&details, annotations)
+	}
+	w.Flush()
+
+	var mismatches strings.Builder
+	var keys []string
+	for key := range versions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if len(versions[key]) < 2 {
+			continue
+		}
+		mismatches.WriteString(fmt.Sprintf("  %s:\n", key))
+		var values []string
+		for value := range versions[key] {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			mismatches.WriteString(fmt.Sprintf("    %s: %s\n", valueOrNone(value), strings.Join(versions[key][value], ", ")))
+		}
+	}
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("# Host and runtime details of %d node(s)\n", len(nodes.Items)))
+	if mismatches.Len() > 0 {
+		summary.WriteString("\n=== Versions differing between nodes ===\n")
+		summary.WriteString(mismatches.String())
+	} else {
+		summary.WriteString("# All nodes run the same kernel, OS image, kubelet, container runtime and NVIDIA driver/CUDA versions\n")
+	}
+	summary.WriteString("\n=== Nodes ===\n")
+	summary.WriteString(output.String())
+	summary.WriteString("\n=== nvidia.com/* labels and annotations ===\n")
+	summary.WriteString(details.String())
+	return summary.String(), nil
+}
+
+// nvidiaEntries returns the nvidia.com/* entries of a label or annotation map as sorted key=value strings
+func nvidiaEntries(entries map[string]string) []string {
+	var result []string
+	for key, value := range entries {
+		if strings.HasPrefix(key, nvidiaPrefix) {
+			result = append(result, key+"="+value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// writeEntries writes key=value entries indented under their heading, or <none>
+func writeEntries(output *strings.Builder, entries []string) {
+	if len(entries) == 0 {
+		output.WriteString("    <none>\n")
+		return
+	}
+	for _, entry := range entries {
+		output.WriteString("    " + entry + "\n")
+	}
+}
@@ -0,0 +1,284 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// OpenShift APIs; RunAI on OpenShift is exposed through Routes and admitted by SCCs
+var (
+	routeGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+	sccGVR   = schema.GroupVersionResource{Group: "security.openshift.io", Version: "v1", Resource: "securitycontextconstraints"}
+)
+
+// sccAnnotation records the SCC that admitted a pod
+const sccAnnotation = "openshift.io/scc"
+
+// servesResource reports whether the API server serves the resource, using discovery
+func (c *Collector) servesResource(gvr schema.GroupVersionResource) bool {
+	resources, err := c.clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource {
+			return true
+		}
+	}
+	return false
+}
+
+// collectOpenShiftInfo collects the Routes and SecurityContextConstraints relevant to a
+// RunAI namespace. Nothing is collected or printed on clusters without the OpenShift APIs.
+func (c *Collector) collectOpenShiftInfo(namespace, logDir string, scriptLog io.Writer) {
+	var actions []collectAction
+	if c.servesResource(routeGVR) {
+		actions = append(actions, collectAction{"OpenShift Routes", "routes.txt", func() (string, error) {
+			return c.getRoutesSummary(namespace)
+		}})
+	}
+	if c.servesResource(sccGVR) {
+		actions = append(actions, collectAction{"OpenShift SecurityContextConstraints", "scc.txt", func() (string, error) {
+			return c.getSCCSummary(namespace)
+		}})
+	}
+
+	c.runActions(namespace, logDir, scriptLog, actions)
+}
+
+// getRoutesSummary lists the namespace Routes that point at a service in the namespace
+func (c *Collector) getRoutesSummary(namespace string) (string, error) {
+	routes, err := c.dynamicClient.Resource(routeGVR).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	services, err := c.clientset.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	serviceNames := map[string]bool{}
+	for _, service := range services.Items {
+		serviceNames[service.Name] = true
+	}
+
+	var output strings.Builder
+	output.WriteString("NAME\tHOST\tPATH\tSERVICE\tPORT\tTLS\tADMITTED\n")
+	matched := 0
+	for _, route := range routes.Items {
+		kind, _, _ := unstructured.NestedString(route.Object, "spec", "to", "kind")
+		service, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name")
+		if (kind != "" && kind != "Service") || !serviceNames[service] {
+			continue
+		}
+		matched++
+
+		host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+		path, _, _ := unstructured.NestedString(route.Object, "spec", "path")
+		port := ""
+		if targetPort, found, _ := unstructured.NestedFieldNoCopy(route.Object, "spec", "port", "targetPort"); found {
+			port = fmt.Sprint(targetPort)
+		}
+		termination, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination")
+		output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			route.GetName(),
+			valueOrNone(host),
+			valueOrNone(path),
+			service,
+			valueOrNone(port),
+			valueOrNone(termination),
+			routeAdmitted(&route),
+		))
+	}
+
+	if matched == 0 {
+		return fmt.Sprintf("No Routes to RunAI services in namespace %s (%d Route(s) in total)\n", namespace, len(routes.Items)), nil
+	}
+	return output.String(), nil
+}
+
+// routeAdmitted summarizes the Admitted condition of every router ingress of a Route
+func routeAdmitted(route *unstructured.Unstructured) string {
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	var states []string
+	for _, ingress := range ingresses {
+		ingressMap, ok := ingress.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		router, _, _ := unstructured.NestedString(ingressMap, "routerName")
+		conditions, _, _ := unstructured.NestedSlice(ingressMap, "conditions")
+		for _, condition := range conditions {
+			conditionMap, ok := condition.(map[string]interface{})
+			if !ok || conditionMap["type"] != "Admitted" {
+				continue
+			}
+			states = append(states, fmt.Sprintf("%s=%v", router, conditionMap["status"]))
+		}
+	}
+	if len(states) == 0 {
+		return "<unknown>"
+	}
+	return strings.Join(states, ",")
+}
+
+// getSCCSummary shows which SecurityContextConstraints the namespace service accounts may
+// use, directly or through RBAC, and which SCC admitted each pod
+func (c *Collector) getSCCSummary(namespace string) (string, error) {
+	sccs, err := c.dynamicClient.Resource(sccGVR).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	// SCC name -> service accounts allowed to use it, and the reason
+	grants := map[string][]string{}
+	userPrefix := fmt.Sprintf("system:serviceaccount:%s:", namespace)
+	namespaceGroup := fmt.Sprintf("system:serviceaccounts:%s", namespace)
+	for _, scc := range sccs.Items {
+		users, _, _ := unstructured.NestedStringSlice(scc.Object, "users")
+		for _, user := range users {
+			if strings.HasPrefix(user, userPrefix) {
+				grants[scc.GetName()] = append(grants[scc.GetName()], strings.TrimPrefix(user, userPrefix)+" (scc users)")
+			}
+		}
+		groups, _, _ := unstructured.NestedStringSlice(scc.Object, "groups")
+		for _, group := range groups {
+			if group == namespaceGroup || group == "system:serviceaccounts" || group == "system:authenticated" {
+				grants[scc.GetName()] = append(grants[scc.GetName()], fmt.Sprintf("all service accounts (scc group %s)", group))
+			}
+		}
+	}
+
+	// RBAC grants of the "use" verb on SCCs to the namespace service accounts
+	sccNames := make([]string, 0, len(sccs.Items))
+	for _, scc := range sccs.Items {
+		sccNames = append(sccNames, scc.GetName())
+	}
+	if err := c.addSCCRoleGrants(namespace, sccNames, grants); err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("=== SCCs usable by service accounts in %s ===\n", namespace))
+	if len(grants) == 0 {
+		output.WriteString("None found\n")
+	}
+	var granted []string
+	for name := range grants {
+		granted = append(granted, name)
+	}
+	sort.Strings(granted)
+	for _, name := range granted {
+		output.WriteString(fmt.Sprintf("%s\n", name))
+		for _, grant := range grants[name] {
+			output.WriteString(fmt.Sprintf("  %s\n", grant))
+		}
+	}
+
+	pods, err := c.listPods(namespace, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	output.WriteString("\n=== SCC admitting each pod ===\n")
+	output.WriteString("POD\tSERVICE ACCOUNT\tSCC\n")
+	for _, pod := range pods {
+		output.WriteString(fmt.Sprintf("%s\t%s\t%s\n", pod.Name, pod.Spec.ServiceAccountName, valueOrNone(pod.Annotations[sccAnnotation])))
+	}
+
+	return output.String(), nil
+}
+
+// addSCCRoleGrants adds the SCCs the namespace service accounts may use through
+// RoleBindings and ClusterRoleBindings granting the "use" verb
+func (c *Collector) addSCCRoleGrants(namespace string, sccNames []string, grants map[string][]string) error {
+	roleBindings, err := c.clientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	clusterRoleBindings, err := c.clientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	type binding struct {
+		name      string
+		namespace string
+		roleRef   rbacv1.RoleRef
+		subjects  []rbacv1.Subject
+	}
+	var bindings []binding
+	for _, rb := range roleBindings.Items {
+		bindings = append(bindings, binding{"RoleBinding " + rb.Name, namespace, rb.RoleRef, rb.Subjects})
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		bindings = append(bindings, binding{"ClusterRoleBinding " + crb.Name, "", crb.RoleRef, crb.Subjects})
+	}
+
+	for _, b := range bindings {
+		accounts := namespaceServiceAccounts(b.subjects, namespace)
+		if len(accounts) == 0 {
+			continue
+		}
+
+		var rules []rbacv1.PolicyRule
+		if b.roleRef.Kind == "Role" {
+			role, err := c.clientset.RbacV1().Roles(b.namespace).Get(context.TODO(), b.roleRef.Name, metav1.GetOptions{})
+			if err != nil {
+				continue
+			}
+			rules = role.Rules
+		} else {
+			role, err := c.clientset.RbacV1().ClusterRoles().Get(context.TODO(), b.roleRef.Name, metav1.GetOptions{})
+			if err != nil {
+				continue
+			}
+			rules = role.Rules
+		}
+
+		for _, sccName := range sccUsableByRules(rules, sccNames) {
+			for _, account := range accounts {
+				grants[sccName] = append(grants[sccName], fmt.Sprintf("%s (%s -> %s %s)", account, b.name, b.roleRef.Kind, b.roleRef.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// sccUsableByRules returns the SCCs the policy rules grant the "use" verb on
+func sccUsableByRules(rules []rbacv1.PolicyRule, sccNames []string) []string {
+	var usable []string
+	for _, rule := range rules {
+		if !containsAny(rule.APIGroups, "security.openshift.io", "*") ||
+			!containsAny(rule.Resources, "securitycontextconstraints", "*") ||
+			!containsAny(rule.Verbs, "use", "*") {
+			continue
+		}
+		names := rule.ResourceNames
+		if len(names) == 0 {
+			names = sccNames
+		}
+		for _, name := range names {
+			if !containsString(usable, name) {
+				usable = append(usable, name)
+			}
+		}
+	}
+	return usable
+}
+
+// containsAny reports whether list contains any of the values
+func containsAny(list []string, values ...string) bool {
+	for _, value := range values {
+		if containsString(list, value) {
+			return true
+		}
+	}
+	return false
+}
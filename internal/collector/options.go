@@ -0,0 +1,397 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// CollectorOptions controls what the collector gathers. It can be loaded from a
+// collection profile file so investigations are reproducible and shareable.
+type CollectorOptions struct {
+	// Namespaces to collect logs and information from
+	Namespaces []string `json:"namespaces,omitempty"`
+	// AllRunAINamespaces additionally collects every namespace carrying a RunAI label
+	// (runai/queue or app.kubernetes.io/managed-by=runai), e.g. project namespaces
+	AllRunAINamespaces bool `json:"allRunaiNamespaces,omitempty"`
+	// NamespaceSelector, when set, selects the namespaces to collect by label selector
+	// (e.g. app.kubernetes.io/part-of=runai) instead of Namespaces
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+	// ExcludeNamespaces drops matching namespaces ('*' wildcard) after discovery
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// ResourceTypes limits the scheduler resources dumped (projects, queues, nodepools, departments)
+	ResourceTypes []string `json:"resourceTypes,omitempty"`
+	// ExtraResources are additional resources dumped as YAML, as group/version/resource[:name]
+	// (version/resource for the core group), e.g. run.ai/v2alpha1/projects
+	ExtraResources []string `json:"extraResources,omitempty"`
+	// LabelSelector limits which pods have their logs collected
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Since only collects log lines newer than this duration (e.g. 1h)
+	Since *metav1.Duration `json:"since,omitempty"`
+	// SinceTime only collects log lines newer than this absolute time; exclusive with Since
+	SinceTime *metav1.Time `json:"sinceTime,omitempty"`
+	// EventsSince only collects events last seen within this duration; defaults to the
+	// Since/SinceTime log window so events line up with the collected logs; 0 keeps every event
+	EventsSince *metav1.Duration `json:"eventsSince,omitempty"`
+	// TailLines only collects this many lines from the end of each log
+	TailLines *int64 `json:"tailLines,omitempty"`
+	// NoTimestamps disables the RFC3339 timestamp prefix on collected log lines
+	NoTimestamps bool `json:"noTimestamps,omitempty"`
+	// Dedup collapses consecutive identical log lines (ignoring timestamps) into one
+	Dedup bool `json:"dedup,omitempty"`
+	// PrettyJSON rewrites JSON-lines container logs as indented .json files
+	PrettyJSON bool `json:"prettyJson,omitempty"`
+	// Grep writes the container log lines matching this regular expression, with
+	// GrepContext lines around each match, to <pod>_<container>.filtered.log;
+	// GrepOnly skips the full log
+	Grep        string `json:"grep,omitempty"`
+	GrepContext int    `json:"grepContext,omitempty"`
+	GrepOnly    bool   `json:"grepOnly,omitempty"`
+	// ExcludeContainers skips logs of containers matching these '*' wildcard patterns;
+	// empty means DefaultExcludeContainers unless IncludeAllContainers is set
+	ExcludeContainers    []string `json:"excludeContainers,omitempty"`
+	IncludeAllContainers bool     `json:"includeAllContainers,omitempty"`
+	// SkipLogs skips pod log collection and only gathers resources and manifests
+	SkipLogs bool `json:"skipLogs,omitempty"`
+	// LogsOnly skips the additional resource and manifest collection
+	LogsOnly bool `json:"logsOnly,omitempty"`
+	// WorkloadsOverview lists every RunAI workload across namespaces with its phase and
+	// requested GPU and CPU in the scheduler archive's workloads-overview.txt
+	WorkloadsOverview bool `json:"workloadsOverview,omitempty"`
+	// Insecure skips certificate verification when the test command checks that the
+	// control plane is reachable
+	Insecure bool `json:"insecure,omitempty"`
+	// SchedulerConfigMap is the scheduler config ConfigMap collected into the scheduler
+	// archive, as [namespace/]name (namespace runai by default); found by name when empty
+	SchedulerConfigMap string `json:"schedulerConfigMap,omitempty"`
+	// HelmHistory lists every revision of each RunAI Helm release in helm-history-<release>.txt
+	HelmHistory bool `json:"helmHistory,omitempty"`
+	// IncludeNamespaceYAML dumps each processed Namespace object (project/department labels)
+	IncludeNamespaceYAML bool `json:"includeNamespaceYaml,omitempty"`
+	// CompressLogsIndividually gzips each .log file inside the archive so single logs
+	// can be decompressed on their own; the archive itself is then stored uncompressed
+	CompressLogsIndividually bool `json:"compressLogsIndividually,omitempty"`
+	// PlainTar writes uncompressed .tar archives instead of .tar.gz
+	PlainTar bool `json:"plainTar,omitempty"`
+	// ArchiveFormat is the archive format and extension: tar.gz (default), tar.zst, zip or tar
+	ArchiveFormat string `json:"archiveFormat,omitempty"`
+	// AppendTo merges the namespace archives into this existing archive, under a
+	// <timestamp>/ directory, instead of leaving them next to it; created when missing
+	AppendTo string `json:"appendTo,omitempty"`
+	// MaxArchiveBytes aborts archiving once the archive would grow beyond this size; 0 is unlimited
+	MaxArchiveBytes int64 `json:"maxArchiveBytes,omitempty"`
+	// OutputPrefix is prepended to every archive and top-level directory name (e.g. CASE-1234_)
+	OutputPrefix string `json:"outputPrefix,omitempty"`
+	// Redact lists regular expressions whose matches are replaced in collected logs and YAML
+	Redact []string `json:"redact,omitempty"`
+
+	// Concurrency bounds the number of parallel API requests for per-item collection
+	Concurrency int `json:"concurrency,omitempty"`
+	// MaxInflight bounds the total number of in-flight API requests across all
+	// worker pools and namespaces; a negative value disables the limit
+	MaxInflight int `json:"maxInflight,omitempty"`
+	// APIQPS and APIBurst configure the client-side API rate limiter. Higher values
+	// speed up collection on large clusters at the cost of more API server load.
+	APIQPS   float32 `json:"apiQps,omitempty"`
+	APIBurst int     `json:"apiBurst,omitempty"`
+	// PageSize is the number of pods requested per List call, so namespaces with
+	// thousands of pods are fetched in bounded responses
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// KeepDir keeps the collection directory after it was archived
+	KeepDir bool `json:"keepDir,omitempty"`
+	// Resume collects into an existing collection directory, skipping container logs
+	// that are already present and non-empty, then archives it
+	Resume string `json:"resume,omitempty"`
+	// NoRetryPartial disables the retry round rerunning the container logs and
+	// resources that failed (other than with Forbidden) before archiving
+	NoRetryPartial bool `json:"noRetryPartial,omitempty"`
+
+	// Output overrides the archive path; "-" streams the archive to the archive writer
+	// (stdout). Only valid when a single namespace is collected.
+	Output string `json:"output,omitempty"`
+
+	// IncludeDNS collects the cluster DNS pod logs and coredns ConfigMap with the runai
+	// namespace; DNSNamespace and DNSSelector locate the DNS pods
+	IncludeDNS   bool   `json:"includeDns,omitempty"`
+	DNSNamespace string `json:"dnsNamespace,omitempty"`
+	DNSSelector  string `json:"dnsSelector,omitempty"`
+
+	// ProbeRegistries checks from the local machine that the registries in image-pull.txt answer
+	ProbeRegistries bool `json:"probeRegistries,omitempty"`
+	// PrometheusService and PrometheusPort select the Prometheus queried for targets and alerts
+	PrometheusService string `json:"prometheusService,omitempty"`
+	PrometheusPort    string `json:"prometheusPort,omitempty"`
+
+	// UTC names archives using UTC instead of local time
+	UTC bool `json:"utc,omitempty"`
+	// LegacyTimestamps names archives with the old minute-granularity formats
+	// (DD-MM-YYYY_HH-MM, or YYYY_MM_DD-HH_MM for workloads)
+	LegacyTimestamps bool `json:"legacyTimestamps,omitempty"`
+
+	// CrashingOnly restricts log collection to crashing or restarting pods and
+	// additionally collects their previous logs and container state summaries
+	CrashingOnly bool `json:"crashingOnly,omitempty"`
+	// RestartWindow joins this many lines of a restarted container's previous instance tail
+	// and current instance start into <pod>_<container>_restart.log; with CrashingOnly it
+	// defaults to DefaultRestartWindow
+	RestartWindow int `json:"restartWindow,omitempty"`
+
+	// Metadata key patterns used to reduce noise in dumped YAML ('*' wildcard).
+	// When KeepAnnotations is non-empty, only matching annotations are kept.
+	StripAnnotations []string `json:"stripAnnotations,omitempty"`
+	KeepAnnotations  []string `json:"keepAnnotations,omitempty"`
+	StripLabels      []string `json:"stripLabels,omitempty"`
+}
+
+// redactedPlaceholder replaces every match of a redaction rule
+const redactedPlaceholder = "[REDACTED]"
+
+// DefaultConcurrency is the default number of parallel API requests
+const DefaultConcurrency = 4
+
+// DefaultMaxInflight is the default bound on total in-flight API requests
+const DefaultMaxInflight = 16
+
+// DefaultExcludeContainers are service-mesh sidecars whose large logs are rarely relevant
+var DefaultExcludeContainers = []string{"istio-proxy", "linkerd-proxy"}
+
+// Default client-side rate limits; client-go's own defaults (5 QPS / 10 burst)
+// throttle read-heavy collection on large clusters
+const (
+	DefaultAPIQPS   = 50
+	DefaultAPIBurst = 100
+)
+
+// DefaultPageSize is the default number of pods requested per List call
+const DefaultPageSize = 500
+
+// Archive name timestamp layouts; the default is second-granularity and sorts chronologically
+const (
+	archiveTimestampLayout        = "2006-01-02T15-04-05Z0700"
+	legacyTimestampLayout         = "02-01-2006_15-04"
+	legacyWorkloadTimestampLayout = "2006_01_02-15_04"
+)
+
+// builtinProfiles are the collection profiles selectable by name
+var builtinProfiles = map[string]CollectorOptions{
+	"minimal": {
+		Namespaces:       []string{"runai"},
+		ResourceTypes:    []string{"projects", "queues"},
+		Since:            &metav1.Duration{Duration: time.Hour},
+		TailLines:        int64Ptr(1000),
+		StripAnnotations: []string{"kubectl.kubernetes.io/last-applied-configuration", "checksum/*"},
+	},
+	"full": {
+		Namespaces:           []string{"runai-backend", "runai"},
+		ResourceTypes:        []string{"projects", "queues", "nodepools", "departments"},
+		IncludeNamespaceYAML: true,
+	},
+}
+
+// DefaultOptions returns the options used when no profile is given
+func DefaultOptions() CollectorOptions {
+	return CollectorOptions{
+		Namespaces:  []string{"runai-backend", "runai"},
+		Concurrency: DefaultConcurrency,
+		MaxInflight: DefaultMaxInflight,
+		APIQPS:      DefaultAPIQPS,
+		APIBurst:    DefaultAPIBurst,
+		PageSize:    DefaultPageSize,
+	}
+}
+
+// BuiltinProfileNames returns the names of the built-in collection profiles
+func BuiltinProfileNames() []string {
+	var names []string
+	for name := range builtinProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadProfile loads a collection profile by built-in name or from a YAML file path.
+// Fields the profile leaves empty keep their default values.
+func LoadProfile(nameOrPath string) (CollectorOptions, error) {
+	if profile, exists := builtinProfiles[nameOrPath]; exists {
+		return withDefaults(profile), nil
+	}
+
+	data, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return CollectorOptions{}, fmt.Errorf("unknown profile %q (built-in profiles: %v): %w", nameOrPath, BuiltinProfileNames(), err)
+	}
+
+	var profile CollectorOptions
+	if err := yaml.UnmarshalStrict(data, &profile); err != nil {
+		return CollectorOptions{}, fmt.Errorf("failed to parse profile %s: %w", nameOrPath, err)
+	}
+
+	return withDefaults(profile), nil
+}
+
+// withDefaults fills empty profile fields from DefaultOptions
+func withDefaults(opts CollectorOptions) CollectorOptions {
+	if len(opts.Namespaces) == 0 {
+		opts.Namespaces = DefaultOptions().Namespaces
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.MaxInflight == 0 {
+		opts.MaxInflight = DefaultMaxInflight
+	}
+	if opts.APIQPS <= 0 {
+		opts.APIQPS = DefaultAPIQPS
+	}
+	if opts.APIBurst <= 0 {
+		opts.APIBurst = DefaultAPIBurst
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	return opts
+}
+
+// Validate checks the options for conflicting settings
+func (o CollectorOptions) Validate() error {
+	if o.Since != nil && o.SinceTime != nil {
+		return fmt.Errorf("since and sinceTime are mutually exclusive")
+	}
+	if o.SkipLogs && o.LogsOnly {
+		return fmt.Errorf("skipLogs and logsOnly are mutually exclusive")
+	}
+	if o.NamespaceSelector != "" {
+		if _, err := labels.Parse(o.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespaceSelector %q: %w", o.NamespaceSelector, err)
+		}
+	}
+	// With a namespace selector, the number of namespaces is only known once they are listed
+	if o.Output != "" && o.NamespaceSelector == "" && len(o.Namespaces) != 1 {
+		return fmt.Errorf("output requires exactly one namespace, got %d (%s)", len(o.Namespaces), strings.Join(o.Namespaces, ", "))
+	}
+	if o.MaxArchiveBytes < 0 {
+		return fmt.Errorf("maxArchiveBytes must not be negative, got %d", o.MaxArchiveBytes)
+	}
+	if o.ArchiveFormat != "" && !containsString(ArchiveFormats, o.ArchiveFormat) {
+		return fmt.Errorf("archiveFormat must be one of %s, got %q", strings.Join(ArchiveFormats, ", "), o.ArchiveFormat)
+	}
+	if o.PlainTar && o.ArchiveFormat != "" && o.ArchiveFormat != FormatTar {
+		return fmt.Errorf("plainTar conflicts with archiveFormat %s", o.ArchiveFormat)
+	}
+	if strings.Count(o.SchedulerConfigMap, "/") > 1 || strings.HasPrefix(o.SchedulerConfigMap, "/") || strings.HasSuffix(o.SchedulerConfigMap, "/") {
+		return fmt.Errorf("schedulerConfigMap must be [namespace/]name, got %q", o.SchedulerConfigMap)
+	}
+	if o.AppendTo != "" {
+		if o.Output != "" {
+			return fmt.Errorf("appendTo conflicts with output")
+		}
+		if _, err := archiveFormatOf(o.AppendTo); err != nil {
+			return fmt.Errorf("invalid appendTo: %w", err)
+		}
+	}
+	if o.PageSize < 0 {
+		return fmt.Errorf("pageSize must not be negative, got %d", o.PageSize)
+	}
+	if o.RestartWindow < 0 {
+		return fmt.Errorf("restartWindow must not be negative, got %d", o.RestartWindow)
+	}
+	if o.GrepContext < 0 {
+		return fmt.Errorf("grepContext must not be negative, got %d", o.GrepContext)
+	}
+	if o.Grep == "" && (o.GrepContext > 0 || o.GrepOnly) {
+		return fmt.Errorf("grepContext and grepOnly require grep")
+	}
+	if strings.ContainsAny(o.OutputPrefix, `/\`) {
+		return fmt.Errorf("outputPrefix must not contain path separators: %q", o.OutputPrefix)
+	}
+	return nil
+}
+
+// logWindow describes which part of the logs is collected
+func (o CollectorOptions) logWindow() string {
+	window := "full history"
+	switch {
+	case o.Since != nil:
+		window = fmt.Sprintf("since %s ago", o.Since.Duration)
+	case o.SinceTime != nil:
+		window = fmt.Sprintf("since %s", o.SinceTime.UTC().Format(time.RFC3339))
+	}
+	if o.TailLines != nil {
+		window += fmt.Sprintf(", last %d lines", *o.TailLines)
+	}
+	return window
+}
+
+// excludedContainers returns the container name patterns whose logs are skipped
+func (o CollectorOptions) excludedContainers() []string {
+	if o.IncludeAllContainers {
+		return nil
+	}
+	if len(o.ExcludeContainers) == 0 {
+		return DefaultExcludeContainers
+	}
+	return o.ExcludeContainers
+}
+
+// eventsCutoff returns the time before which events are dropped, if events are limited
+func (o CollectorOptions) eventsCutoff(now time.Time) (time.Time, bool) {
+	switch {
+	case o.EventsSince != nil:
+		return now.Add(-o.EventsSince.Duration), o.EventsSince.Duration > 0
+	case o.Since != nil:
+		return now.Add(-o.Since.Duration), true
+	case o.SinceTime != nil:
+		return o.SinceTime.Time, true
+	}
+	return time.Time{}, false
+}
+
+// DefaultSchedulerEventsSince is the window of the scheduler command's scheduling events
+// when no events or log window is given, so a snapshot shows recent decisions only
+const DefaultSchedulerEventsSince = time.Hour
+
+// schedulerEventsCutoff is eventsCutoff with the scheduler command's default window
+func (o CollectorOptions) schedulerEventsCutoff(now time.Time) (time.Time, bool) {
+	if o.EventsSince == nil && o.Since == nil && o.SinceTime == nil {
+		return now.Add(-DefaultSchedulerEventsSince), true
+	}
+	return o.eventsCutoff(now)
+}
+
+// compileRedactRules compiles the redaction regular expressions
+func compileRedactRules(rules []string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction rule %q: %w", rule, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// compileGrep compiles the log line filter; nil means no filtering
+func compileGrep(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grep expression %q: %w", expr, err)
+	}
+	return pattern, nil
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getPodDisruptionBudgetsYAML gets the namespace PodDisruptionBudgets as YAML
+func (c *Collector) getPodDisruptionBudgetsYAML(namespace string) (string, error) {
+	pdbs, err := c.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(pdbs)
+}
+
+// getPodDisruptionBudgetsSummary summarizes the PodDisruptionBudgets that can block node
+// drains and upgrades, similar to kubectl get pdb
+func (c *Collector) getPodDisruptionBudgetsSummary(namespace string) (string, error) {
+	pdbs, err := c.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	if len(pdbs.Items) == 0 {
+		output.WriteString(fmt.Sprintf("No PodDisruptionBudgets in namespace %s\n", namespace))
+		return output.String(), nil
+	}
+
+	output.WriteString("NAME\tMIN AVAILABLE\tMAX UNAVAILABLE\tCURRENT HEALTHY\tDESIRED HEALTHY\tEXPECTED PODS\tALLOWED DISRUPTIONS\n")
+	for _, pdb := range pdbs.Items {
+		minAvailable, maxUnavailable := "N/A", "N/A"
+		if pdb.Spec.MinAvailable != nil {
+			minAvailable = pdb.Spec.MinAvailable.String()
+		}
+		if pdb.Spec.MaxUnavailable != nil {
+			maxUnavailable = pdb.Spec.MaxUnavailable.String()
+		}
+
+		line := fmt.Sprintf("%s\t%s\t%s\t%d\t%d\t%d\t%d",
+			pdb.Name,
+			minAvailable,
+			maxUnavailable,
+			pdb.Status.CurrentHealthy,
+			pdb.Status.DesiredHealthy,
+			pdb.Status.ExpectedPods,
+			pdb.Status.DisruptionsAllowed,
+		)
+		if pdb.Status.DisruptionsAllowed == 0 && pdb.Status.ExpectedPods > 0 {
+			line += "\t(blocks evictions)"
+		}
+		output.WriteString(line + "\n")
+	}
+
+	return output.String(), nil
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recentPodThreshold flags pods created (or recreated) more recently than this
+const recentPodThreshold = time.Hour
+
+// peerAgeFactor flags pods whose age differs from their replicas' median age by this factor
+const peerAgeFactor = 10
+
+// podHealth is the restart count and age of a single pod
+type podHealth struct {
+	name     string
+	restarts int32
+	age      time.Duration
+	flags    []string
+}
+
+// getPodHealth ranks pods by restart count and flags recently created pods and pods
+// much younger or older than the other pods of the same controller. The pods are
+// those listed for the pod list.
+func (c *Collector) getPodHealth(listPods func() ([]corev1.Pod, error)) (string, error) {
+	pods, err := listPods()
+	if err != nil {
+		return "", err
+	}
+
+	var health []*podHealth
+	peers := map[string][]*podHealth{}
+	for _, pod := range pods {
+		entry := &podHealth{
+			name: pod.Name,
+			age:  time.Since(pod.CreationTimestamp.Time).Truncate(time.Second),
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			entry.restarts += status.RestartCount
+		}
+		if entry.age < recentPodThreshold {
+			entry.flags = append(entry.flags, fmt.Sprintf("recently created (<%s)", recentPodThreshold))
+		}
+
+		health = append(health, entry)
+		// Replicas share their controller; name prefixes do not tell StatefulSet or
+		// DaemonSet pods of different workloads apart
+		if owner := metav1.GetControllerOf(&pod); owner != nil {
+			key := owner.Kind + "/" + owner.Name
+			peers[key] = append(peers[key], entry)
+		}
+	}
+
+	// Compare each replica to the median age of its peers
+	for _, group := range peers {
+		if len(group) < 2 {
+			continue
+		}
+		median := medianAge(group)
+		for _, entry := range group {
+			switch {
+			case entry.age*peerAgeFactor < median:
+				entry.flags = append(entry.flags, fmt.Sprintf("much younger than peers (median %s)", median))
+			case entry.age > median*peerAgeFactor:
+				entry.flags = append(entry.flags, fmt.Sprintf("much older than peers (median %s)", median))
+			}
+		}
+	}
+
+	sort.SliceStable(health, func(i, j int) bool {
+		if health[i].restarts != health[j].restarts {
+			return health[i].restarts > health[j].restarts
+		}
+		return health[i].name < health[j].name
+	})
+
+	var output strings.Builder
+	output.WriteString("NAME\tRESTARTS\tAGE\tFLAGS\n")
+	for _, entry := range health {
+		flags := "-"
+		if len(entry.flags) > 0 {
+			flags = strings.Join(entry.flags, "; ")
+		}
+		output.WriteString(fmt.Sprintf("%s\t%d\t%s\t%s\n", entry.name, entry.restarts, entry.age, flags))
+	}
+
+	return output.String(), nil
+}
+
+// medianAge returns the median pod age of a group
+func medianAge(group []*podHealth) time.Duration {
+	ages := make([]time.Duration, 0, len(group))
+	for _, entry := range group {
+		ages = append(ages, entry.age)
+	}
+	sort.Slice(ages, func(i, j int) bool { return ages[i] < ages[j] })
+
+	middle := len(ages) / 2
+	if len(ages)%2 == 0 {
+		return (ages[middle-1] + ages[middle]) / 2
+	}
+	return ages[middle]
+}
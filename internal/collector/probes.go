@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// collectProbes writes probes.txt: each container's liveness, readiness and startup probes
+// with its pod's Ready condition and the latest probe failure, pods that are not Ready first
+func (c *Collector) collectProbes(namespace string, listPods func() ([]corev1.Pod, error), logDir string, scriptLog io.Writer) {
+	console.Printf("  📊 Collecting health probes...\n")
+	fmt.Fprintf(scriptLog, "Collecting health probes...\n")
+
+	c.runAction(namespace, logDir, scriptLog, collectAction{"Health probes", "probes.txt", func() (string, error) {
+		return c.getProbesSummary(namespace, listPods)
+	}})
+}
+
+// getProbesSummary builds the probes.txt content for a namespace from its already
+// listed pods
+func (c *Collector) getProbesSummary(namespace string, listPods func() ([]corev1.Pod, error)) (string, error) {
+	listed, err := listPods()
+	if err != nil {
+		return "", err
+	}
+	// The listed pods are shared with the other steps, so they are sorted in a copy
+	pods := append([]corev1.Pod(nil), listed...)
+
+	// Probe failures are only reported as Unhealthy events, limited to the events window;
+	// an unreadable event list still leaves the probe definitions
+	failures := map[string]corev1.Event{}
+	events, eventsErr := c.clientset.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{FieldSelector: "reason=Unhealthy"})
+	if eventsErr == nil {
+		for _, event := range c.filterEvents(events.Items) {
+			key := event.InvolvedObject.Name + "/" + event.InvolvedObject.FieldPath
+			if latest, found := failures[key]; !found || eventTime(event).After(eventTime(latest)) {
+				failures[key] = event
+			}
+		}
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		iReady, jReady := isPodReady(&pods[i]), isPodReady(&pods[j])
+		if iReady != jReady {
+			return !iReady
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Health probes in namespace %s; pods that are not Ready first\n", namespace))
+	if eventsErr != nil {
+		output.WriteString(fmt.Sprintf("# Probe failures unavailable: failed to list Unhealthy events: %v\n", eventsErr))
+	}
+	for i := range pods {
+		pod := &pods[i]
+		output.WriteString(fmt.Sprintf("\npod/%s (%s, %s)\n", pod.Name, pod.Status.Phase, describeReadyCondition(pod)))
+
+		// Probe events name the container by its field path in the pod spec
+		fieldPaths := map[string]string{}
+		containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+		for _, container := range pod.Spec.InitContainers {
+			fieldPaths[container.Name] = fmt.Sprintf("spec.initContainers{%s}", container.Name)
+		}
+		for _, container := range pod.Spec.Containers {
+			fieldPaths[container.Name] = fmt.Sprintf("spec.containers{%s}", container.Name)
+			containers = append(containers, container)
+		}
+
+		for _, container := range containers {
+			if container.LivenessProbe == nil && container.ReadinessProbe == nil && container.StartupProbe == nil {
+				continue
+			}
+			status, _ := findContainerStatus(pod, container.Name)
+			output.WriteString(fmt.Sprintf("  %s (ready=%t, restarts=%d)\n", container.Name, status.Ready, status.RestartCount))
+			output.WriteString(fmt.Sprintf("    liveness:  %s\n", describeProbe(container.LivenessProbe)))
+			output.WriteString(fmt.Sprintf("    readiness: %s\n", describeProbe(container.ReadinessProbe)))
+			output.WriteString(fmt.Sprintf("    startup:   %s\n", describeProbe(container.StartupProbe)))
+			if event, found := failures[pod.Name+"/"+fieldPaths[container.Name]]; found {
+				output.WriteString(fmt.Sprintf("    last failure: %s (x%d) %s\n", eventTime(event).UTC().Format(time.RFC3339), event.Count, strings.TrimSpace(event.Message)))
+			}
+		}
+	}
+	return c.redact(output.String()), nil
+}
+
+// describeReadyCondition summarizes the pod's Ready condition with its reason and message
+func describeReadyCondition(pod *corev1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodReady {
+			continue
+		}
+		description := fmt.Sprintf("Ready=%s", condition.Status)
+		if condition.Reason != "" {
+			description += " " + condition.Reason
+		}
+		if condition.Message != "" {
+			description += ": " + condition.Message
+		}
+		return description
+	}
+	return "Ready condition not reported"
+}
+
+// describeProbe formats a probe like kubectl describe does
+func describeProbe(probe *corev1.Probe) string {
+	if probe == nil {
+		return "<none>"
+	}
+
+	var action string
+	switch {
+	case probe.HTTPGet != nil:
+		action = fmt.Sprintf("http-get %s://%s:%s%s", strings.ToLower(string(probe.HTTPGet.Scheme)), probe.HTTPGet.Host, probe.HTTPGet.Port.String(), probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%s", probe.TCPSocket.Host, probe.TCPSocket.Port.String())
+	case probe.Exec != nil:
+		action = fmt.Sprintf("exec [%s]", strings.Join(probe.Exec.Command, " "))
+	case probe.GRPC != nil:
+		action = fmt.Sprintf("grpc :%d", probe.GRPC.Port)
+		if probe.GRPC.Service != nil && *probe.GRPC.Service != "" {
+			action += " service=" + *probe.GRPC.Service
+		}
+	default:
+		action = "unknown"
+	}
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		action, probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds, probe.SuccessThreshold, probe.FailureThreshold)
+}
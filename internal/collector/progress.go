@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Progress event statuses
+const (
+	progressStart   = "start"
+	progressOK      = "ok"
+	progressFailed  = "failed"
+	progressSkipped = "skipped"
+)
+
+// progressEvent is one collection step in the NDJSON progress stream
+type progressEvent struct {
+	TS        time.Time `json:"ts"`
+	Phase     string    `json:"phase"`
+	Namespace string    `json:"namespace,omitempty"`
+	Pod       string    `json:"pod,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Item      string    `json:"item,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// progressStream writes progress events as newline-delimited JSON, one line per write
+type progressStream struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// SetProgressWriter makes the collector emit every collection step to w as a
+// newline-delimited JSON event, independently of the console output
+func (c *Collector) SetProgressWriter(w io.Writer) {
+	c.progress = &progressStream{encoder: json.NewEncoder(w)}
+}
+
+// emit writes a progress event when a progress writer is set. Without an explicit status,
+// the event is ok, or failed with err.
+func (c *Collector) emit(event progressEvent, err error) {
+	if c.progress == nil {
+		return
+	}
+	event.TS = time.Now().UTC()
+	if event.Status == "" {
+		event.Status = progressOK
+		if err != nil {
+			event.Status = progressFailed
+		}
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	c.progress.mu.Lock()
+	defer c.progress.mu.Unlock()
+	// A broken progress consumer must not abort the collection
+	_ = c.progress.encoder.Encode(event)
+}
@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"nmcrun/internal/console"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Default Prometheus service queried for scrape targets and alerts
+const (
+	DefaultPrometheusService = "prometheus-operated"
+	DefaultPrometheusPort    = "9090"
+)
+
+// collectPrometheusInfo saves the Prometheus scrape target state and active alerts.
+// Prometheus is reached through the API server service proxy, so no local port is needed.
+// It is skipped cleanly when the configured service does not exist in the namespace.
+func (c *Collector) collectPrometheusInfo(namespace, logDir string, scriptLog io.Writer) {
+	service := c.opts.PrometheusService
+	if service == "" {
+		service = DefaultPrometheusService
+	}
+	port := c.opts.PrometheusPort
+	if port == "" {
+		port = DefaultPrometheusPort
+	}
+
+	console.Printf("  📈 Checking for Prometheus service %s/%s...\n", namespace, service)
+	fmt.Fprintf(scriptLog, "Checking for Prometheus service %s/%s...\n", namespace, service)
+
+	endpoints := []struct {
+		name     string
+		path     string
+		filename string
+	}{
+		{"Prometheus scrape targets", "/api/v1/targets", "prometheus-targets.json"},
+		{"Prometheus alerts", "/api/v1/alerts", "prometheus-alerts.json"},
+	}
+	var actions []collectAction
+	for _, endpoint := range endpoints {
+		actions = append(actions, collectAction{endpoint.name, endpoint.filename, func() (string, error) {
+			output, err := c.queryPrometheus(namespace, service, port, endpoint.path)
+			return string(output), err
+		}})
+	}
+
+	if _, err := c.clientset.CoreV1().Services(namespace).Get(context.TODO(), service, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			console.Printf("    ⏭️  Prometheus service not found, skipping metrics collection\n")
+			fmt.Fprintf(scriptLog, "  Prometheus service not found, skipping metrics collection\n")
+			return
+		}
+		c.failStep(namespace, "prometheus", "Prometheus scrape targets and alerts", scriptLog, err, func() error {
+			return writeActionsOutput(actions, logDir)
+		})
+		return
+	}
+
+	for _, action := range actions {
+		console.Printf("  📈 Collecting %s...\n", action.name)
+		fmt.Fprintf(scriptLog, "Collecting %s...\n", action.name)
+		c.runAction(namespace, logDir, scriptLog, action)
+	}
+}
+
+// queryPrometheus fetches a Prometheus HTTP API path through the service proxy and indents the JSON
+func (c *Collector) queryPrometheus(namespace, service, port, path string) ([]byte, error) {
+	data, err := c.clientset.CoreV1().Services(namespace).ProxyGet("http", service, port, path, nil).DoRaw(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		// Keep the raw response if it is not valid JSON
+		return data, nil
+	}
+	return indented.Bytes(), nil
+}
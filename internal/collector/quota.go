@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// collectQuotaInfo collects the ResourceQuotas and LimitRanges of a namespace, which
+// explain scheduling failures and admission rejections caused by namespace limits
+func (c *Collector) collectQuotaInfo(namespace, logDir string, scriptLog io.Writer) {
+	actions := []collectAction{
+		{"ResourceQuotas", "resourcequotas.yaml", func() (string, error) {
+			return c.getResourceQuotasYAML(namespace)
+		}},
+		{"LimitRanges", "limitranges.yaml", func() (string, error) {
+			return c.getLimitRangesYAML(namespace)
+		}},
+		{"Quota usage", "quota.txt", func() (string, error) {
+			return c.getQuotaUsage(namespace)
+		}},
+	}
+
+	c.runActions(namespace, logDir, scriptLog, actions)
+}
+
+// getResourceQuotasYAML gets the namespace ResourceQuotas as YAML
+func (c *Collector) getResourceQuotasYAML(namespace string) (string, error) {
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(quotas)
+}
+
+// getLimitRangesYAML gets the namespace LimitRanges as YAML
+func (c *Collector) getLimitRangesYAML(namespace string) (string, error) {
+	limitRanges, err := c.clientset.CoreV1().LimitRanges(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(limitRanges)
+}
+
+// getQuotaUsage shows used vs hard limits per resource, similar to kubectl describe quota
+func (c *Collector) getQuotaUsage(namespace string) (string, error) {
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	if len(quotas.Items) == 0 {
+		output.WriteString(fmt.Sprintf("No ResourceQuotas in namespace %s\n", namespace))
+		return output.String(), nil
+	}
+
+	for _, quota := range quotas.Items {
+		output.WriteString(fmt.Sprintf("Name: %s\n", quota.Name))
+		output.WriteString("RESOURCE\tUSED\tHARD\n")
+
+		// Status is empty until the quota controller has reconciled the quota
+		hardLimits := quota.Status.Hard
+		if len(hardLimits) == 0 {
+			hardLimits = quota.Spec.Hard
+		}
+
+		var resources []string
+		for name := range hardLimits {
+			resources = append(resources, string(name))
+		}
+		sort.Strings(resources)
+
+		for _, resource := range resources {
+			name := corev1.ResourceName(resource)
+			used := "0"
+			if quantity, exists := quota.Status.Used[name]; exists {
+				used = quantity.String()
+			}
+			hard := hardLimits[name]
+			output.WriteString(fmt.Sprintf("%s\t%s\t%s\n", resource, used, hard.String()))
+		}
+		output.WriteString("\n")
+	}
+
+	return output.String(), nil
+}
@@ -0,0 +1,190 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// collectRBACInfo dumps the ServiceAccounts, Roles and RoleBindings of a RunAI namespace and
+// the ClusterRoleBindings bound to its service accounts, which explain "forbidden" failures
+// after RBAC was edited by hand
+func (c *Collector) collectRBACInfo(namespace, logDir string, scriptLog io.Writer) {
+	actions := []collectAction{
+		{"ServiceAccounts", "serviceaccounts.yaml", func() (string, error) {
+			return c.getServiceAccountsYAML(namespace)
+		}},
+		{"Roles", "roles.yaml", func() (string, error) {
+			return c.getRolesYAML(namespace)
+		}},
+		{"RoleBindings", "rolebindings.yaml", func() (string, error) {
+			return c.getRoleBindingsYAML(namespace)
+		}},
+		{"ClusterRoleBindings for namespace service accounts", "clusterrolebindings.yaml", func() (string, error) {
+			return c.getClusterRoleBindingsYAML(namespace)
+		}},
+		{"RBAC summary", "rbac.txt", func() (string, error) {
+			return c.getRBACSummary(namespace)
+		}},
+	}
+
+	c.runActions(namespace, logDir, scriptLog, actions)
+}
+
+// getServiceAccountsYAML gets the namespace ServiceAccounts as YAML
+func (c *Collector) getServiceAccountsYAML(namespace string) (string, error) {
+	serviceAccounts, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(serviceAccounts)
+}
+
+// getRolesYAML gets the namespace Roles as YAML
+func (c *Collector) getRolesYAML(namespace string) (string, error) {
+	roles, err := c.clientset.RbacV1().Roles(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(roles)
+}
+
+// getRoleBindingsYAML gets the namespace RoleBindings as YAML
+func (c *Collector) getRoleBindingsYAML(namespace string) (string, error) {
+	roleBindings, err := c.clientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(roleBindings)
+}
+
+// getClusterRoleBindingsYAML gets the ClusterRoleBindings with a subject that is a
+// service account of the namespace as YAML
+func (c *Collector) getClusterRoleBindingsYAML(namespace string) (string, error) {
+	bindings, err := c.namespaceClusterRoleBindings(namespace)
+	if err != nil {
+		return "", err
+	}
+	return c.objectToYAML(&rbacv1.ClusterRoleBindingList{Items: bindings})
+}
+
+// namespaceClusterRoleBindings lists the ClusterRoleBindings bound to service accounts of the namespace
+func (c *Collector) namespaceClusterRoleBindings(namespace string) ([]rbacv1.ClusterRoleBinding, error) {
+	list, err := c.clientset.RbacV1().ClusterRoleBindings().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings []rbacv1.ClusterRoleBinding
+	for _, binding := range list.Items {
+		if len(namespaceServiceAccounts(binding.Subjects, namespace)) > 0 {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+// namespaceServiceAccounts returns the names of the subjects that are service accounts of the namespace
+func namespaceServiceAccounts(subjects []rbacv1.Subject, namespace string) []string {
+	var accounts []string
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == namespace {
+			accounts = append(accounts, subject.Name)
+		}
+	}
+	return accounts
+}
+
+// getRBACSummary lists the roles bound to each service account of the namespace and flags
+// bindings to missing roles or missing service accounts
+func (c *Collector) getRBACSummary(namespace string) (string, error) {
+	serviceAccounts, err := c.clientset.CoreV1().ServiceAccounts(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	roleBindings, err := c.clientset.RbacV1().RoleBindings(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	clusterRoleBindings, err := c.namespaceClusterRoleBindings(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	// Each referenced role is looked up once to detect bindings to deleted roles
+	roleExists := map[string]bool{}
+	checkRole := func(roleNamespace string, ref rbacv1.RoleRef) bool {
+		key := ref.Kind + "/" + roleNamespace + "/" + ref.Name
+		if exists, seen := roleExists[key]; seen {
+			return exists
+		}
+		var err error
+		if ref.Kind == "Role" {
+			_, err = c.clientset.RbacV1().Roles(roleNamespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		} else {
+			_, err = c.clientset.RbacV1().ClusterRoles().Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		}
+		roleExists[key] = !apierrors.IsNotFound(err)
+		return roleExists[key]
+	}
+
+	grants := map[string][]string{}
+	var problems []string
+	addGrants := func(binding, roleNamespace string, ref rbacv1.RoleRef, subjects []rbacv1.Subject) {
+		grant := fmt.Sprintf("%s %s (%s)", ref.Kind, ref.Name, binding)
+		if !checkRole(roleNamespace, ref) {
+			grant += " ⚠️ role not found"
+			problems = append(problems, fmt.Sprintf("%s references missing %s %s", binding, ref.Kind, ref.Name))
+		}
+		for _, account := range namespaceServiceAccounts(subjects, namespace) {
+			grants[account] = append(grants[account], grant)
+		}
+	}
+	for _, binding := range roleBindings.Items {
+		addGrants("RoleBinding "+binding.Name, namespace, binding.RoleRef, binding.Subjects)
+	}
+	for _, binding := range clusterRoleBindings {
+		addGrants("ClusterRoleBinding "+binding.Name, "", binding.RoleRef, binding.Subjects)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("=== Roles bound to service accounts in %s ===\n", namespace))
+	existing := map[string]bool{}
+	for _, serviceAccount := range serviceAccounts.Items {
+		existing[serviceAccount.Name] = true
+		output.WriteString(fmt.Sprintf("%s\n", serviceAccount.Name))
+		if len(grants[serviceAccount.Name]) == 0 {
+			output.WriteString("  <no bindings>\n")
+		}
+		for _, grant := range grants[serviceAccount.Name] {
+			output.WriteString(fmt.Sprintf("  %s\n", grant))
+		}
+	}
+
+	var missing []string
+	for account := range grants {
+		if !existing[account] {
+			missing = append(missing, account)
+		}
+	}
+	sort.Strings(missing)
+	for _, account := range missing {
+		problems = append(problems, fmt.Sprintf("bindings reference missing ServiceAccount %s: %s", account, strings.Join(grants[account], "; ")))
+	}
+
+	output.WriteString("\n=== Problems ===\n")
+	if len(problems) == 0 {
+		output.WriteString("None found\n")
+	}
+	for _, problem := range problems {
+		output.WriteString(fmt.Sprintf("⚠️ %s\n", problem))
+	}
+
+	return output.String(), nil
+}
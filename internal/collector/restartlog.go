@@ -0,0 +1,140 @@
+package collector
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultRestartWindow is the number of lines joined on each side of a restart with --crashing-only
+const DefaultRestartWindow = 200
+
+// restartWindow returns the number of lines of the previous instance's tail and the
+// current instance's start joined into a restart log, or 0 when none is written
+func (o CollectorOptions) restartWindow() int {
+	if o.RestartWindow > 0 {
+		return o.RestartWindow
+	}
+	if o.CrashingOnly {
+		return DefaultRestartWindow
+	}
+	return 0
+}
+
+// collectRestartLog writes the tail of a restarted container's previous instance and the
+// start of its current instance to one file, separated by a "--- container restarted ---"
+// line describing how the previous instance ended. Containers that never restarted are skipped.
+func (c *Collector) collectRestartLog(pod, container, namespace, logFile string, window int, scriptLog io.Writer) {
+	podObj, err := c.clientset.CoreV1().Pods(namespace).Get(context.TODO(), pod, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(scriptLog, "      Warning: Failed to get pod %s for the restart log: %v\n", pod, err)
+		return
+	}
+	status, found := findContainerStatus(podObj, container)
+	if !found || status.RestartCount == 0 {
+		return
+	}
+
+	tailLines := int64(window)
+	previous, err := c.streamPodLogs(namespace, pod, &corev1.PodLogOptions{
+		Container:  container,
+		Timestamps: !c.opts.NoTimestamps,
+		Previous:   true,
+		TailLines:  &tailLines,
+	})
+	if err != nil {
+		fmt.Fprintf(scriptLog, "      No restart log for container %s: %v\n", container, err)
+		return
+	}
+	current, err := c.podLogHead(namespace, pod, container, window)
+	if err != nil {
+		fmt.Fprintf(scriptLog, "      No restart log for container %s: %v\n", container, err)
+		return
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Last %d lines of the previous instance and first %d lines of the current instance of %s/%s\n", window, window, pod, container))
+	output.WriteString(previous)
+	if previous != "" && !strings.HasSuffix(previous, "\n") {
+		output.WriteString("\n")
+	}
+	output.WriteString(fmt.Sprintf("--- container restarted (%s, restart %d) ---\n", describeLastTermination(status), status.RestartCount))
+	output.WriteString(current)
+
+	if err := os.WriteFile(logFile, []byte(output.String()), 0644); err != nil {
+		console.Printf("      ⚠️  Warning: Failed to write restart log for container: %s\n", container)
+		fmt.Fprintf(scriptLog, "      ⚠ Warning: Failed to write restart log for container %s: %v\n", container, err)
+		return
+	}
+	console.Printf("      ✅ Restart log saved\n")
+	fmt.Fprintf(scriptLog, "      ✓ Restart log saved to: %s\n", logFile)
+}
+
+// podLogHead returns the first lines of the current instance's log within the log window,
+// closing the stream as soon as they are read
+func (c *Collector) podLogHead(namespace, pod, container string, lines int) (string, error) {
+	logOptions := &corev1.PodLogOptions{
+		Container:  container,
+		Timestamps: !c.opts.NoTimestamps,
+		SinceTime:  c.opts.SinceTime,
+	}
+	if c.opts.Since != nil {
+		sinceSeconds := int64(c.opts.Since.Seconds())
+		logOptions.SinceSeconds = &sinceSeconds
+	}
+
+	podLogs, err := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, logOptions).Stream(context.TODO())
+	if err != nil {
+		return "", err
+	}
+	defer podLogs.Close()
+
+	var output strings.Builder
+	scanner := bufio.NewScanner(podLogs)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for read := 0; read < lines && scanner.Scan(); read++ {
+		output.WriteString(scanner.Text())
+		output.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return c.redact(output.String()), nil
+}
+
+// findContainerStatus returns the status of a regular or init container of the pod
+func findContainerStatus(pod *corev1.Pod, container string) (corev1.ContainerStatus, bool) {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for _, status := range statuses {
+			if status.Name == container {
+				return status, true
+			}
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// describeLastTermination summarizes how the previous instance of a container ended
+func describeLastTermination(status corev1.ContainerStatus) string {
+	terminated := status.LastTerminationState.Terminated
+	if terminated == nil {
+		return "previous state unknown"
+	}
+	description := fmt.Sprintf("exit code %d", terminated.ExitCode)
+	if terminated.Reason != "" {
+		description += " " + terminated.Reason
+	}
+	if !terminated.FinishedAt.IsZero() {
+		description += " at " + terminated.FinishedAt.UTC().Format(time.RFC3339)
+	}
+	return description
+}
@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"nmcrun/internal/console"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// failedItem is a collection step that failed. retry runs it again; it is nil for
+// items that are not retried.
+type failedItem struct {
+	name  string
+	retry func() error
+}
+
+// failureList tracks the failed items of the namespace being collected
+type failureList struct {
+	mu    sync.Mutex
+	items []failedItem
+}
+
+// recordFailure remembers a failed item, which makes the collection of the namespace
+// incomplete, and queues it for the retry round. Forbidden errors are not transient,
+// so those items are not retried.
+func (c *Collector) recordFailure(name string, err error, retry func() error) {
+	if c.opts.NoRetryPartial || apierrors.IsForbidden(err) {
+		retry = nil
+	}
+	c.failed.mu.Lock()
+	defer c.failed.mu.Unlock()
+	c.failed.items = append(c.failed.items, failedItem{name: name, retry: retry})
+}
+
+// takeFailures returns the recorded failed items and clears the list
+func (c *Collector) takeFailures() []failedItem {
+	c.failed.mu.Lock()
+	defer c.failed.mu.Unlock()
+	items := c.failed.items
+	c.failed.items = nil
+	return items
+}
+
+// retryFailed runs every retryable item that failed during the main pass once more, so
+// transient API errors do not leave gaps in the archive. Recovered items are removed from
+// the failures; the others stay recorded.
+func (c *Collector) retryFailed(scriptLog io.Writer) {
+	var retryable, remaining []failedItem
+	for _, item := range c.takeFailures() {
+		if item.retry != nil {
+			retryable = append(retryable, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+	defer func() {
+		c.failed.mu.Lock()
+		defer c.failed.mu.Unlock()
+		c.failed.items = append(remaining, c.failed.items...)
+	}()
+	if len(retryable) == 0 {
+		return
+	}
+
+	console.Printf("\n🔁 === Retrying %d Failed Items ===\n", len(retryable))
+	fmt.Fprintf(scriptLog, "\n=== Retrying %d Failed Items ===\n", len(retryable))
+
+	recovered := 0
+	for i, item := range retryable {
+		console.Printf("  🔁 [%d/%d] Retrying %s...\n", i+1, len(retryable), item.name)
+		if err := item.retry(); err != nil {
+			console.Printf("    ⚠️  Warning: Retry failed for %s: %v\n", item.name, err)
+			fmt.Fprintf(scriptLog, "  ⚠ Warning: Retry failed for %s: %v\n", item.name, err)
+			remaining = append(remaining, item)
+			continue
+		}
+		recovered++
+		console.Printf("    ✅ %s recovered\n", item.name)
+		fmt.Fprintf(scriptLog, "  ✓ %s recovered on retry\n", item.name)
+	}
+
+	console.Printf("  🔁 Recovered %d of %d failed items\n", recovered, len(retryable))
+	fmt.Fprintf(scriptLog, "  Recovered %d of %d failed items\n", recovered, len(retryable))
+}
+
+// retryContainerLogs returns a retry for a container whose logs could not be collected
+func (c *Collector) retryContainerLogs(pod, container, namespace, logFile string, isInit bool, summary *namespaceSummary) func() error {
+	return func() error {
+		if _, err := c.collectContainerLogs(pod, container, namespace, logFile, isInit); err != nil {
+			return err
+		}
+		summary.containers++
+		return nil
+	}
+}
+
+// writeActionOutput runs a collection action and writes its output to filePath
+func writeActionOutput(cmd func() (string, error), filePath string) error {
+	output, err := cmd()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, []byte(output), 0644)
+}
+
+// writeActionsOutput runs every action and writes its output to logDir
+func writeActionsOutput(actions []collectAction, logDir string) error {
+	var errs []error
+	for _, action := range actions {
+		if err := writeActionOutput(action.cmd, filepath.Join(logDir, action.filename)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", action.filename, err))
+		}
+	}
+	return errors.Join(errs...)
+}
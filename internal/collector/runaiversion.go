@@ -0,0 +1,131 @@
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// runaiVersion is a RunAI cluster major.minor version
+type runaiVersion struct {
+	major int
+	minor int
+}
+
+func (v runaiVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// less reports whether v is an older version than other
+func (v runaiVersion) less(other runaiVersion) bool {
+	return v.major < other.major || (v.major == other.major && v.minor < other.minor)
+}
+
+// versionRange is the RunAI versions an API is served in; zero bounds are open.
+// since is inclusive, until is exclusive.
+type versionRange struct {
+	since runaiVersion
+	until runaiVersion
+}
+
+func (r versionRange) contains(v runaiVersion) bool {
+	if r.since != (runaiVersion{}) && v.less(r.since) {
+		return false
+	}
+	if r.until != (runaiVersion{}) && !v.less(r.until) {
+		return false
+	}
+	return true
+}
+
+// gvrAvailability lists the RunAI versions serving version-specific APIs. APIs not
+// listed are assumed to exist in every version and are always tried.
+var gvrAvailability = map[schema.GroupVersionResource]versionRange{
+	// Workload CRDs replaced RunAIJob-only tracking in 2.18
+	{Group: "run.ai", Version: "v1", Resource: "trainingworkloads"}:                   {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v2alpha1", Resource: "trainingworkloads"}:             {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v1", Resource: "interactiveworkloads"}:                {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v2alpha1", Resource: "interactiveworkloads"}:          {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v1", Resource: "inferenceworkloads"}:                  {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v2alpha1", Resource: "inferenceworkloads"}:            {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v1", Resource: "distributedworkloads"}:                {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v2alpha1", Resource: "distributedworkloads"}:          {since: runaiVersion{2, 18}},
+	{Group: "run.ai", Version: "v1", Resource: "externalworkloads"}:                   {since: runaiVersion{2, 19}},
+	{Group: "run.ai", Version: "v2alpha1", Resource: "externalworkloads"}:             {since: runaiVersion{2, 19}},
+	{Group: "run.ai", Version: "v1", Resource: "distributedinferenceworkloads"}:       {since: runaiVersion{2, 21}},
+	{Group: "run.ai", Version: "v2alpha1", Resource: "distributedinferenceworkloads"}: {since: runaiVersion{2, 21}},
+	// Queues moved to scheduling.run.ai/v2 in 2.16
+	{Group: "scheduling.run.ai", Version: "v1", Resource: "queues"}: {until: runaiVersion{2, 16}},
+	{Group: "scheduling.run.ai", Version: "v2", Resource: "queues"}: {since: runaiVersion{2, 16}},
+	// Node pools were introduced in 2.8
+	{Group: "run.ai", Version: "v1alpha1", Resource: "nodepools"}: {since: runaiVersion{2, 8}},
+}
+
+// parseRunAIVersion parses the major and minor version from strings like "2.19.3" or "v2.19.3-rc.1"
+func parseRunAIVersion(version string) (runaiVersion, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	if len(parts) < 2 {
+		return runaiVersion{}, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return runaiVersion{}, false
+	}
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return runaiVersion{}, false
+	}
+	return runaiVersion{major: major, minor: minor}, true
+}
+
+// clusterRunAIVersion detects the RunAI cluster version once per collector, preferring
+// the runai-public configmap over the runaiconfig image tag. ok is false when the
+// version cannot be determined, in which case every known API is tried.
+func (c *Collector) clusterRunAIVersion() (runaiVersion, bool) {
+	c.runaiVersionOnce.Do(func() {
+		configVersion, clusterVersion := c.getRunAIVersions(c.findRunAINamespace())
+		if c.runaiVersion, c.runaiVersionKnown = parseRunAIVersion(clusterVersion); !c.runaiVersionKnown {
+			c.runaiVersion, c.runaiVersionKnown = parseRunAIVersion(configVersion)
+		}
+	})
+	return c.runaiVersion, c.runaiVersionKnown
+}
+
+// gvrsFor returns the GVR candidates of a resource type that can exist in the
+// cluster's RunAI version. A GVR the availability table rules out is still kept when
+// the API server serves it, so a wrong table entry does not drop data.
+func (c *Collector) gvrsFor(resource string) ([]schema.GroupVersionResource, error) {
+	candidates, exists := gvrCandidates[resource]
+	if !exists {
+		return nil, fmt.Errorf("unknown resource type: %s", resource)
+	}
+
+	version, known := c.clusterRunAIVersion()
+	if !known {
+		return candidates, nil
+	}
+
+	var gvrs []schema.GroupVersionResource
+	for _, gvr := range candidates {
+		if availability, listed := gvrAvailability[gvr]; listed && !availability.contains(version) && !c.servesResource(gvr) {
+			continue
+		}
+		gvrs = append(gvrs, gvr)
+	}
+	if len(gvrs) == 0 {
+		return nil, &resourceUnavailableError{resource: resource, version: version}
+	}
+	return gvrs, nil
+}
+
+// resourceUnavailableError reports a resource type that does not exist in the cluster's RunAI version
+type resourceUnavailableError struct {
+	resource string
+	version  runaiVersion
+}
+
+func (e *resourceUnavailableError) Error() string {
+	return fmt.Sprintf("%s is not available in RunAI %s", e.resource, e.version)
+}
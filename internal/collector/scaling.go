@@ -0,0 +1,210 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"nmcrun/internal/console"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// scaledObjectsGVR is the KEDA ScaledObject resource
+var scaledObjectsGVR = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "scaledobjects"}
+
+// isInferenceWorkload reports whether the canonical workload type autoscales
+func isInferenceWorkload(canonicalType string) bool {
+	return canonicalType == "inferenceworkloads" || canonicalType == "distributedinferenceworkloads"
+}
+
+// getWorkloadScaling writes the HPAs and KEDA ScaledObjects targeting the workload's
+// ksvc or deployments as YAML, plus <workload>_<type>_scaling.txt with their replica counts
+// and conditions. A workload without autoscalers writes no files.
+func (c *Collector) getWorkloadScaling(namespace, workload, typeSafe string) ([]string, error) {
+	console.Printf("  📄 Getting autoscalers...\n")
+
+	targets, err := c.workloadScaleTargets(namespace, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	hpaList, err := c.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HorizontalPodAutoscalers: %w", err)
+	}
+	hpas := &autoscalingv2.HorizontalPodAutoscalerList{}
+	hpasByName := map[string]*autoscalingv2.HorizontalPodAutoscaler{}
+	for i := range hpaList.Items {
+		hpasByName[hpaList.Items[i].Name] = &hpaList.Items[i]
+		if targetsWorkload(hpaList.Items[i].Spec.ScaleTargetRef.Name, workload, targets) {
+			hpas.Items = append(hpas.Items, hpaList.Items[i])
+		}
+	}
+
+	scaledObjects := &unstructured.UnstructuredList{}
+	if c.servesResource(scaledObjectsGVR) {
+		list, err := c.dynamicClient.Resource(scaledObjectsGVR).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ScaledObjects: %w", err)
+		}
+		for _, item := range list.Items {
+			target, _, _ := unstructured.NestedString(item.Object, "spec", "scaleTargetRef", "name")
+			if targetsWorkload(target, workload, targets) {
+				scaledObjects.Items = append(scaledObjects.Items, item)
+			}
+		}
+	}
+
+	if len(hpas.Items) == 0 && len(scaledObjects.Items) == 0 {
+		console.Printf("    ℹ️  No HPA or ScaledObject targets the workload (this is normal without autoscaling)\n")
+		return nil, nil
+	}
+
+	var outputFiles []string
+	if len(hpas.Items) > 0 {
+		filename := fmt.Sprintf("%s_%s_hpa.yaml", workload, typeSafe)
+		if err := c.writeObjectYAML(filename, hpas); err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, filename)
+	}
+	if len(scaledObjects.Items) > 0 {
+		filename := fmt.Sprintf("%s_%s_scaledobjects.yaml", workload, typeSafe)
+		if err := c.writeObjectYAML(filename, scaledObjects); err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, filename)
+	}
+
+	var output strings.Builder
+	for i := range hpas.Items {
+		describeHPA(&output, &hpas.Items[i])
+	}
+	for _, scaledObject := range scaledObjects.Items {
+		describeScaledObject(&output, scaledObject, hpasByName)
+	}
+	filename := fmt.Sprintf("%s_%s_scaling.txt", workload, typeSafe)
+	if err := os.WriteFile(filename, []byte(c.redact(output.String())), 0644); err != nil {
+		return nil, err
+	}
+	outputFiles = append(outputFiles, filename)
+
+	console.Printf("    ✅ %d HPA(s) and %d ScaledObject(s) retrieved\n", len(hpas.Items), len(scaledObjects.Items))
+	return outputFiles, nil
+}
+
+// workloadScaleTargets returns the names an autoscaler of the workload may target: the
+// workload (its ksvc) and the deployments labelled with the workload name
+func (c *Collector) workloadScaleTargets(namespace, workload string) (map[string]bool, error) {
+	targets := map[string]bool{workload: true}
+	deployments, err := c.clientset.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("workloadName=%s", workload),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workload deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		targets[deployment.Name] = true
+	}
+	return targets, nil
+}
+
+// targetsWorkload reports whether a scale target name belongs to the workload. Knative
+// revisions and their deployments are named after the ksvc, so prefixes match too.
+func targetsWorkload(target, workload string, targets map[string]bool) bool {
+	return targets[target] || strings.HasPrefix(target, workload+"-")
+}
+
+// writeObjectYAML writes an object as YAML to filename
+func (c *Collector) writeObjectYAML(filename string, obj runtime.Object) error {
+	output, err := c.objectToYAML(obj)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(output), 0644)
+}
+
+// describeHPA writes an HPA's target, replica counts and conditions
+func describeHPA(output *strings.Builder, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+	output.WriteString(fmt.Sprintf("=== HorizontalPodAutoscaler %s ===\n", hpa.Name))
+	output.WriteString(fmt.Sprintf("Target:           %s/%s\n", hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name))
+	output.WriteString(fmt.Sprintf("Min/Max replicas: %d/%d\n", minReplicas, hpa.Spec.MaxReplicas))
+	output.WriteString(fmt.Sprintf("Current replicas: %d\n", hpa.Status.CurrentReplicas))
+	output.WriteString(fmt.Sprintf("Desired replicas: %d\n", hpa.Status.DesiredReplicas))
+	lastScale := "<never>"
+	if hpa.Status.LastScaleTime != nil {
+		lastScale = hpa.Status.LastScaleTime.UTC().Format(time.RFC3339)
+	}
+	output.WriteString(fmt.Sprintf("Last scale time:  %s\n", lastScale))
+	output.WriteString("Conditions:\n")
+	for _, condition := range hpa.Status.Conditions {
+		output.WriteString(fmt.Sprintf("  %s=%s %s: %s\n", condition.Type, condition.Status, condition.Reason, condition.Message))
+	}
+	output.WriteString("\n")
+}
+
+// describeScaledObject writes a ScaledObject's target, triggers, conditions and the
+// replica counts of the HPA KEDA manages for it
+func describeScaledObject(output *strings.Builder, scaledObject unstructured.Unstructured, hpas map[string]*autoscalingv2.HorizontalPodAutoscaler) {
+	object := scaledObject.Object
+	kind, _, _ := unstructured.NestedString(object, "spec", "scaleTargetRef", "kind")
+	target, _, _ := unstructured.NestedString(object, "spec", "scaleTargetRef", "name")
+	if kind == "" {
+		kind = "Deployment"
+	}
+	output.WriteString(fmt.Sprintf("=== ScaledObject %s ===\n", scaledObject.GetName()))
+	output.WriteString(fmt.Sprintf("Target:           %s/%s\n", kind, target))
+
+	minReplicas, minFound, _ := unstructured.NestedInt64(object, "spec", "minReplicaCount")
+	maxReplicas, maxFound, _ := unstructured.NestedInt64(object, "spec", "maxReplicaCount")
+	if !minFound {
+		minReplicas = 0
+	}
+	if !maxFound {
+		maxReplicas = 100
+	}
+	output.WriteString(fmt.Sprintf("Min/Max replicas: %d/%d\n", minReplicas, maxReplicas))
+
+	triggers, _, _ := unstructured.NestedSlice(object, "spec", "triggers")
+	var triggerTypes []string
+	for _, trigger := range triggers {
+		if fields, ok := trigger.(map[string]interface{}); ok {
+			if triggerType, ok := fields["type"].(string); ok {
+				triggerTypes = append(triggerTypes, triggerType)
+			}
+		}
+	}
+	output.WriteString(fmt.Sprintf("Triggers:         %s\n", valueOrNone(strings.Join(triggerTypes, ", "))))
+
+	if lastActive, found, _ := unstructured.NestedString(object, "status", "lastActiveTime"); found {
+		output.WriteString(fmt.Sprintf("Last active:      %s\n", lastActive))
+	}
+	hpaName, _, _ := unstructured.NestedString(object, "status", "hpaName")
+	if hpa, exists := hpas[hpaName]; exists {
+		output.WriteString(fmt.Sprintf("Managed HPA:      %s (current %d, desired %d replicas)\n", hpaName, hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas))
+	} else {
+		output.WriteString(fmt.Sprintf("Managed HPA:      %s\n", valueOrNone(hpaName)))
+	}
+
+	output.WriteString("Conditions:\n")
+	conditions, _, _ := unstructured.NestedSlice(object, "status", "conditions")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		output.WriteString(fmt.Sprintf("  %v=%v %v: %v\n", condition["type"], condition["status"], condition["reason"], condition["message"]))
+	}
+	output.WriteString("\n")
+}
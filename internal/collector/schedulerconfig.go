@@ -0,0 +1,198 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// schedulerConfigNamespaces are searched for the scheduler config ConfigMap when
+// --scheduler-config is not given, after the namespace RunAI is installed in
+var schedulerConfigNamespaces = []string{"runai-scheduler", "kai-scheduler"}
+
+// findSchedulerConfigMaps returns the ConfigMap named by SchedulerConfigMap, or else every
+// ConfigMap with "scheduler" in its name holding a scheduler configuration
+func (c *Collector) findSchedulerConfigMaps() ([]corev1.ConfigMap, error) {
+	if c.opts.SchedulerConfigMap != "" {
+		namespace, name := c.findRunAINamespace(), c.opts.SchedulerConfigMap
+		if before, after, found := strings.Cut(name, "/"); found {
+			namespace, name = before, after
+		}
+		cm, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.ConfigMap{*cm}, nil
+	}
+
+	namespaces := append([]string{c.findRunAINamespace()}, schedulerConfigNamespaces...)
+	var found []corev1.ConfigMap
+	for _, namespace := range namespaces {
+		list, err := c.clientset.CoreV1().ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ConfigMaps in %s: %w", namespace, err)
+		}
+		for _, cm := range list.Items {
+			if !strings.Contains(cm.Name, "scheduler") {
+				continue
+			}
+			for _, data := range cm.Data {
+				if _, ok := parseSchedulerConfig(data); ok {
+					found = append(found, cm)
+					break
+				}
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no scheduler config ConfigMap found in %s (set one with --scheduler-config [namespace/]name)", strings.Join(namespaces, ", "))
+	}
+	return found, nil
+}
+
+// getSchedulerConfig returns the scheduler config ConfigMaps as YAML and the summary of
+// their active actions and plugins
+func (c *Collector) getSchedulerConfig() (string, string, error) {
+	configMaps, err := c.findSchedulerConfigMaps()
+	if err != nil {
+		return "", "", err
+	}
+
+	var manifests []string
+	var summary strings.Builder
+	summary.WriteString("# Scheduler actions and plugins (from scheduler-config.yaml)\n")
+	for i := range configMaps {
+		cm := &configMaps[i]
+		manifest, err := c.objectToYAML(cm)
+		if err != nil {
+			return "", "", err
+		}
+		manifests = append(manifests, manifest)
+
+		var keys []string
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			config, ok := parseSchedulerConfig(cm.Data[key])
+			if !ok {
+				continue
+			}
+			summary.WriteString(fmt.Sprintf("\n=== %s/%s (%s) ===\n", cm.Namespace, cm.Name, key))
+			summary.WriteString(formatSchedulerConfig(config))
+		}
+	}
+	return strings.Join(manifests, "---\n"), summary.String(), nil
+}
+
+// parseSchedulerConfig parses a ConfigMap value as a RunAI scheduler configuration (actions
+// and plugin tiers) or a KubeSchedulerConfiguration (profiles)
+func parseSchedulerConfig(data string) (map[string]interface{}, bool) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return nil, false
+	}
+	_, hasTiers := config["tiers"]
+	_, hasActions := config["actions"]
+	_, hasProfiles := config["profiles"]
+	return config, hasTiers || hasActions || hasProfiles
+}
+
+// formatSchedulerConfig lists the actions and, per tier or profile, the enabled plugins
+// with their weights and arguments
+func formatSchedulerConfig(config map[string]interface{}) string {
+	var output strings.Builder
+
+	if actions, ok := config["actions"].(string); ok {
+		output.WriteString("actions (in order):\n")
+		for _, action := range strings.Split(actions, ",") {
+			output.WriteString(fmt.Sprintf("  %s\n", strings.TrimSpace(action)))
+		}
+	}
+
+	tiers, _ := config["tiers"].([]interface{})
+	for i, tier := range tiers {
+		output.WriteString(fmt.Sprintf("tier %d plugins:\n", i+1))
+		tierMap, _ := tier.(map[string]interface{})
+		plugins, _ := tierMap["plugins"].([]interface{})
+		for _, plugin := range plugins {
+			pluginMap, _ := plugin.(map[string]interface{})
+			output.WriteString(fmt.Sprintf("  %s\n", describeSchedulerPlugin(pluginMap)))
+		}
+	}
+
+	profiles, _ := config["profiles"].([]interface{})
+	for _, profile := range profiles {
+		profileMap, _ := profile.(map[string]interface{})
+		schedulerName, _ := profileMap["schedulerName"].(string)
+		output.WriteString(fmt.Sprintf("profile %s:\n", valueOrNone(schedulerName)))
+		extensionPoints, _ := profileMap["plugins"].(map[string]interface{})
+		var names []string
+		for name := range extensionPoints {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			point, _ := extensionPoints[name].(map[string]interface{})
+			for _, state := range []string{"enabled", "disabled"} {
+				plugins, _ := point[state].([]interface{})
+				for _, plugin := range plugins {
+					pluginMap, _ := plugin.(map[string]interface{})
+					output.WriteString(fmt.Sprintf("  %s %s: %s\n", name, state, describeSchedulerPlugin(pluginMap)))
+				}
+			}
+		}
+		pluginConfigs, _ := profileMap["pluginConfig"].([]interface{})
+		for _, pluginConfig := range pluginConfigs {
+			pluginMap, _ := pluginConfig.(map[string]interface{})
+			output.WriteString(fmt.Sprintf("  pluginConfig: %s\n", describeSchedulerPlugin(pluginMap)))
+		}
+	}
+
+	if output.Len() == 0 {
+		return "no actions, tiers or profiles set (scheduler defaults)\n"
+	}
+	return output.String()
+}
+
+// describeSchedulerPlugin formats a plugin entry as its name followed by its other settings,
+// e.g. its weight and arguments, sorted by key
+func describeSchedulerPlugin(plugin map[string]interface{}) string {
+	var keys []string
+	for key := range plugin {
+		if key != "name" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	description := fmt.Sprint(plugin["name"])
+	for _, key := range keys {
+		value := plugin[key]
+		if nested, ok := value.(map[string]interface{}); ok {
+			settings := map[string]string{}
+			flattenSettings(key, nested, settings)
+			var paths []string
+			for path := range settings {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				description += fmt.Sprintf(" %s=%s", path, settings[path])
+			}
+			continue
+		}
+		description += fmt.Sprintf(" %s=%v", key, value)
+	}
+	return description
+}
@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getSchedulingAnalysis writes <workload>_<type>_scheduling-analysis.txt explaining, for every
+// Pending workload pod, which nodes it cannot land on because of untolerated taints, its node
+// selector or cordons
+func (c *Collector) getSchedulingAnalysis(namespace, workload, typeSafe string) (string, error) {
+	filename := fmt.Sprintf("%s_%s_scheduling-analysis.txt", workload, typeSafe)
+	console.Printf("  📄 Analyzing scheduling of Pending pods...\n")
+
+	pods, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", workload))
+	if err != nil {
+		return "", err
+	}
+
+	var pending []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodPending && pod.Spec.NodeName == "" {
+			pending = append(pending, pod)
+		}
+	}
+
+	var output strings.Builder
+	if len(pending) == 0 {
+		output.WriteString(fmt.Sprintf("No Pending pods for workload %s (%d pod(s) in total)\n", workload, len(pods.Items)))
+	} else {
+		nodes, err := c.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return "", err
+		}
+		sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+
+		var sections []string
+		for i := range pending {
+			sections = append(sections, analyzePodScheduling(&pending[i], nodes.Items))
+		}
+		output.WriteString(strings.Join(sections, "\n---\n\n"))
+	}
+
+	if err := os.WriteFile(filename, []byte(c.redact(output.String())), 0644); err != nil {
+		return "", err
+	}
+
+	console.Printf("    ✅ Scheduling analysis written (%d Pending pod(s))\n", len(pending))
+	return filename, nil
+}
+
+// analyzePodScheduling lists the reasons each node is excluded for a Pending pod
+func analyzePodScheduling(pod *corev1.Pod, nodes []corev1.Node) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Pod: %s\n", pod.Name))
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Message != "" {
+			output.WriteString(fmt.Sprintf("Scheduler message: %s\n", condition.Message))
+		}
+	}
+
+	var candidates []string
+	for i := range nodes {
+		reasons := nodeExclusionReasons(pod, &nodes[i])
+		if len(reasons) == 0 {
+			candidates = append(candidates, nodes[i].Name)
+			continue
+		}
+		for _, reason := range reasons {
+			output.WriteString(fmt.Sprintf("  node %s %s\n", nodes[i].Name, reason))
+		}
+	}
+
+	if len(candidates) == 0 {
+		output.WriteString("No node passes the taint, node selector and cordon checks\n")
+	} else {
+		output.WriteString(fmt.Sprintf("Nodes without taint/selector conflicts (check resources and quotas): %s\n", strings.Join(candidates, ", ")))
+	}
+	return output.String()
+}
+
+// nodeExclusionReasons returns why the pod cannot be scheduled on the node based on
+// cordons, its node selector and NoSchedule/NoExecute taints it does not tolerate
+func nodeExclusionReasons(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+	if node.Spec.Unschedulable {
+		reasons = append(reasons, "is cordoned (unschedulable)")
+	}
+
+	var selectorKeys []string
+	for key := range pod.Spec.NodeSelector {
+		selectorKeys = append(selectorKeys, key)
+	}
+	sort.Strings(selectorKeys)
+	for _, key := range selectorKeys {
+		if value := pod.Spec.NodeSelector[key]; node.Labels[key] != value {
+			reasons = append(reasons, fmt.Sprintf("does not match nodeSelector %s=%s", key, value))
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || toleratesTaint(pod.Spec.Tolerations, taint) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("has taint %s not tolerated", taint.ToString()))
+	}
+	return reasons
+}
+
+// toleratesTaint reports whether any of the tolerations tolerates the taint
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package collector
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// isNamespaced reports whether the API server serves the resource as namespaced, using
+// discovery. known is false when discovery does not list the resource.
+func (c *Collector) isNamespaced(gvr schema.GroupVersionResource) (namespaced bool, known bool) {
+	c.scopeMu.Lock()
+	defer c.scopeMu.Unlock()
+
+	if c.namespacedGVRs == nil {
+		c.namespacedGVRs = map[schema.GroupVersionResource]bool{}
+	}
+	if namespaced, cached := c.namespacedGVRs[gvr]; cached {
+		return namespaced, true
+	}
+
+	resources, err := c.clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false, false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource {
+			c.namespacedGVRs[gvr] = resource.Namespaced
+			return resource.Namespaced, true
+		}
+	}
+	return false, false
+}
+
+// resourceClient returns the dynamic client for a resource, scoped to the namespace only
+// when the resource is namespaced, so cluster-scoped kinds are not requested with one.
+// Resources unknown to discovery keep the given namespace.
+func (c *Collector) resourceClient(gvr schema.GroupVersionResource, namespace string) dynamic.ResourceInterface {
+	if namespace == "" {
+		return c.dynamicClient.Resource(gvr)
+	}
+	if namespaced, known := c.isNamespaced(gvr); known && !namespaced {
+		return c.dynamicClient.Resource(gvr)
+	}
+	return c.dynamicClient.Resource(gvr).Namespace(namespace)
+}
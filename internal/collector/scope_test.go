@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetResourceAsYAMLClusterScoped(t *testing.T) {
+	nodepools := schema.GroupVersionResource{Group: "run.ai", Version: "v1alpha1", Resource: "nodepools"}
+
+	// A cluster-scoped object only exists outside every namespace
+	nodepool := &unstructured.Unstructured{}
+	nodepool.SetAPIVersion("run.ai/v1alpha1")
+	nodepool.SetKind("Nodepool")
+	nodepool.SetName("default")
+	// Every known resource can be listed, e.g. the runaiconfig read to detect the RunAI version
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, gvrs := range gvrCandidates {
+		for _, gvr := range gvrs {
+			listKinds[gvr] = gvr.Resource + "List"
+		}
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, nodepool)
+
+	clientset := fake.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: nodepools.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: "nodepools", Kind: "Nodepool", Namespaced: false}},
+	}}
+
+	c := &Collector{clientset: clientset, dynamicClient: dynamicClient}
+	output, err := c.getResourceAsYAML("runai", "nodepools", "default")
+	if err != nil {
+		t.Fatalf("getResourceAsYAML: %v", err)
+	}
+	if !strings.Contains(output, "name: default") {
+		t.Errorf("getResourceAsYAML returned %q, want the default nodepool", output)
+	}
+
+	if namespaced, known := c.isNamespaced(nodepools); !known || namespaced {
+		t.Errorf("isNamespaced = %v, %v, want cluster-scoped and known", namespaced, known)
+	}
+}
+
+func TestGVRsForKeepsServedResourcesTheVersionTableRulesOut(t *testing.T) {
+	// RunAI 2.15 predates scheduling.run.ai/v2 queues according to the table
+	clientset := fake.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "scheduling.run.ai/v2",
+		APIResources: []metav1.APIResource{{Name: "queues", Kind: "Queue"}},
+	}}
+	c := &Collector{clientset: clientset}
+	c.runaiVersionOnce.Do(func() {
+		c.runaiVersion, c.runaiVersionKnown = runaiVersion{2, 15}, true
+	})
+
+	gvrs, err := c.gvrsFor("queues")
+	if err != nil {
+		t.Fatalf("gvrsFor: %v", err)
+	}
+	var versions []string
+	for _, gvr := range gvrs {
+		versions = append(versions, gvr.Version)
+	}
+	if got, want := strings.Join(versions, ","), "v2,v1"; got != want {
+		t.Errorf("gvrsFor(queues) versions = %s, want %s", got, want)
+	}
+
+	// Without the API being served, the table decides
+	c.clientset = fake.NewSimpleClientset()
+	gvrs, err = c.gvrsFor("queues")
+	if err != nil {
+		t.Fatalf("gvrsFor: %v", err)
+	}
+	if len(gvrs) != 1 || gvrs[0].Version != "v1" {
+		t.Errorf("gvrsFor(queues) = %v, want only v1", gvrs)
+	}
+}
@@ -0,0 +1,227 @@
+package collector
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"nmcrun/internal/console"
+)
+
+// selfTestFiles are the sample files archived by the self-test, relative to the log directory
+var selfTestFiles = map[string]string{
+	"script.log":                       "=== Log Collection Started ===\n",
+	"logs/runai-scheduler_main.log":    "2024-06-01T10:00:00.000000000Z scheduler started\n2024-06-01T10:00:01.000000000Z cycle completed\n",
+	"logs/runai-operator_manager.log":  "2024-06-01T10:00:00.000000000Z reconciling runaiconfig\n",
+	"info/pods.txt":                    "NAME\tREADY\tSTATUS\nrunai-scheduler\t1/1\tRunning\n",
+	"info/nested/runaiconfig.yaml":     "apiVersion: run.ai/v1\nkind: RunaiConfig\n",
+	"info/empty.txt":                   "",
+	"info/unicode-ünïcødé.txt":         "✅ ⚠️ 🔴\n",
+	"logs/large_container.log":         strings.Repeat("2024-06-01T10:00:00.000000000Z repeated log line for compression\n", 20000),
+	"info/binary.bin":                  string([]byte{0, 1, 2, 255, 254, 0, 128}),
+	"info/no-trailing-newline.txt":     "last line",
+	"info/with spaces in the name.txt": "spaces\n",
+}
+
+// selfTestVariant is an archive format combination exercised by the self-test
+type selfTestVariant struct {
+	name string
+	opts CollectorOptions
+}
+
+var selfTestVariants = []selfTestVariant{
+	{name: "tar.gz", opts: CollectorOptions{}},
+	{name: "plain tar", opts: CollectorOptions{PlainTar: true}},
+	{name: "tar.zst", opts: CollectorOptions{ArchiveFormat: FormatTarZst}},
+	{name: "zip", opts: CollectorOptions{ArchiveFormat: FormatZip}},
+	{name: "individually compressed logs", opts: CollectorOptions{CompressLogsIndividually: true}},
+}
+
+// SelfTest runs the real archive creation and verification code against sample files in a
+// temporary directory and checks that everything reads back unchanged. No cluster is needed.
+func SelfTest() error {
+	console.Println("🧪 Running archive self-test...")
+
+	failed := 0
+	for _, variant := range selfTestVariants {
+		c := &Collector{opts: variant.opts}
+		checks := []struct {
+			name string
+			run  func(dir string) error
+		}{
+			{"namespace archive (" + variant.name + ")", c.selfTestNamespaceArchive},
+			{"workload archive (" + variant.name + ")", c.selfTestWorkloadArchive},
+		}
+
+		for _, check := range checks {
+			dir, err := os.MkdirTemp("", "nmcrun-selftest-")
+			if err != nil {
+				return fmt.Errorf("failed to create temp directory: %w", err)
+			}
+			err = check.run(dir)
+			os.RemoveAll(dir)
+
+			if err != nil {
+				console.Printf("  ❌ %s: %v\n", check.name, err)
+				failed++
+			} else {
+				console.Printf("  ✅ %s\n", check.name)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("archive self-test failed: %d check(s) failed", failed)
+	}
+	console.Println("🟢 Archive self-test passed")
+	return nil
+}
+
+// selfTestNamespaceArchive archives a sample log directory with createArchive and compares
+// the archive contents with the files left on disk
+func (c *Collector) selfTestNamespaceArchive(dir string) error {
+	logDir := filepath.Join(dir, "selftest-logs")
+	if err := writeSelfTestFiles(logDir); err != nil {
+		return err
+	}
+
+	archiveName := logDir + c.archiveExtension()
+	entries, err := c.createArchive(logDir, archiveName, io.Discard)
+	if err != nil {
+		return fmt.Errorf("createArchive: %w", err)
+	}
+	if err := c.verifyArchive(archiveName, entries); err != nil {
+		return fmt.Errorf("verifyArchive: %w", err)
+	}
+
+	// Compare with the files on disk, which createArchive may have compressed in place
+	expected := map[string][]byte{}
+	err = filepath.Walk(logDir, func(file string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		name, err := archiveEntryName(dir, file)
+		if err != nil {
+			return err
+		}
+		expected[name], err = os.ReadFile(file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.compareArchive(archiveName, expected)
+}
+
+// selfTestWorkloadArchive archives flat sample files with createWorkloadArchive and
+// compares the archive contents with the originals
+func (c *Collector) selfTestWorkloadArchive(dir string) error {
+	var files []string
+	expected := map[string][]byte{}
+	for name, content := range selfTestFiles {
+		file := filepath.Join(dir, strings.ReplaceAll(name, "/", "_"))
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			return err
+		}
+		files = append(files, file)
+		expected[filepath.Base(file)] = []byte(content)
+	}
+
+	archiveName := filepath.Join(dir, "selftest-workload"+c.archiveExtension())
+	if err := c.createWorkloadArchive(archiveName, files); err != nil {
+		return fmt.Errorf("createWorkloadArchive: %w", err)
+	}
+	// The files and SHA256SUMS
+	if err := c.verifyArchive(archiveName, len(files)+1); err != nil {
+		return fmt.Errorf("verifyArchive: %w", err)
+	}
+
+	return c.compareArchive(archiveName, expected)
+}
+
+// writeSelfTestFiles writes the sample files below logDir
+func writeSelfTestFiles(logDir string) error {
+	for name, content := range selfTestFiles {
+		file := filepath.Join(logDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareArchive checks that the archive holds exactly the expected regular files and contents
+func (c *Collector) compareArchive(archiveName string, expected map[string][]byte) error {
+	archiveReader, err := openArchive(archiveName, c.opts.archiveFormat())
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer archiveReader.Close()
+
+	found := 0
+	for {
+		entry, err := archiveReader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if strings.HasSuffix(entry.name, "/") {
+			continue
+		}
+		if path.Base(entry.name) == checksumsFileName {
+			if err := compareChecksums(archiveReader, path.Dir(entry.name), expected); err != nil {
+				return err
+			}
+			continue
+		}
+
+		want, ok := expected[entry.name]
+		if !ok {
+			return fmt.Errorf("unexpected entry %s", entry.name)
+		}
+		got, err := io.ReadAll(archiveReader)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.name, err)
+		}
+		if !bytes.Equal(got, want) {
+			return fmt.Errorf("content of %s differs (%d bytes, expected %d)", entry.name, len(got), len(want))
+		}
+		found++
+	}
+
+	if found != len(expected) {
+		return fmt.Errorf("archive has %d files, expected %d", found, len(expected))
+	}
+	return nil
+}
+
+// compareChecksums checks that a SHA256SUMS entry lists the checksum of every expected file
+// below dir
+func compareChecksums(r io.Reader, dir string, expected map[string][]byte) error {
+	sums, err := parseChecksums(r, dir)
+	if err != nil {
+		return err
+	}
+	for name, content := range expected {
+		if dir != "." && !strings.HasPrefix(name, dir+"/") {
+			continue
+		}
+		sum := sha256.Sum256(content)
+		if sums[name] != hex.EncodeToString(sum[:]) {
+			return fmt.Errorf("%s lists checksum %q for %s, expected %x", checksumsFileName, sums[name], name, sum)
+		}
+	}
+	return nil
+}
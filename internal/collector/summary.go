@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"nmcrun/internal/console"
+)
+
+// namespaceSummary aggregates the outcome of collecting a single namespace. failures
+// names the items that could not be collected; errors counts namespace-level errors.
+type namespaceSummary struct {
+	namespace   string
+	pods        int
+	containers  int
+	failures    []string
+	errors      int
+	archiveSize int64
+}
+
+// printRunSummary prints a per-namespace table of what was collected and what went wrong
+func printRunSummary(summaries []*namespaceSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	console.Println("\n📋 === Collection Summary ===")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tNAMESPACE\tPODS\tCONTAINERS\tFAILED\tERRORS\tARCHIVE")
+	for _, s := range summaries {
+		icon := "🟢"
+		if len(s.failures) > 0 {
+			icon = "🟡"
+		}
+		if s.errors > 0 {
+			icon = "🔴"
+		}
+		// Resolve plain-mode status labels before the table is aligned
+		icon = console.Format(icon)
+
+		archive := "-"
+		if s.archiveSize > 0 {
+			archive = fmt.Sprintf("%.2f MB", float64(s.archiveSize)/1024/1024)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\n", icon, s.namespace, s.pods, s.containers, len(s.failures), s.errors, archive)
+	}
+	w.Flush()
+}
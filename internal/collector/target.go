@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TargetContext returns the kubeconfig context being collected from, or the
+// authentication method when the cluster was not selected through kubeconfig
+func (c *Collector) TargetContext() string {
+	if c.authMethod != authKubeconfig {
+		return c.authMethod
+	}
+	if name, err := c.getCurrentContext(); err == nil {
+		return name
+	}
+	return "unknown"
+}
+
+// ConfirmTarget guards against collecting from the wrong cluster. When expectContext
+// is set the target context must match it; otherwise, when confirm is set, the user
+// must confirm the printed target interactively.
+func (c *Collector) ConfirmTarget(in io.Reader, out io.Writer, expectContext string, confirm bool) error {
+	if expectContext == "" && !confirm {
+		return nil
+	}
+
+	targetContext := c.TargetContext()
+	fmt.Fprintf(out, "🎯 Target context: %s\n", targetContext)
+	fmt.Fprintf(out, "🎯 Target cluster: %s\n", c.config.Host)
+
+	if expectContext != "" {
+		if targetContext != expectContext {
+			return fmt.Errorf("current context %q does not match expected context %q", targetContext, expectContext)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "Proceed with collection from this cluster? [y/N]: ")
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return fmt.Errorf("no confirmation given: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("collection cancelled")
+}
@@ -0,0 +1,42 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// timing is the wall-clock duration of one collection phase
+type timing struct {
+	phase    string
+	duration time.Duration
+}
+
+// phaseTimings records how long each collection phase took
+type phaseTimings struct {
+	entries []timing
+}
+
+// record adds the time elapsed since start for a phase
+func (t *phaseTimings) record(phase string, start time.Time) time.Duration {
+	duration := time.Since(start)
+	t.entries = append(t.entries, timing{phase: phase, duration: duration})
+	return duration
+}
+
+// format renders the recorded timings as a tab-separated table
+func (t *phaseTimings) format() string {
+	var output strings.Builder
+	output.WriteString("# Collection timings (wall clock)\n\n")
+	output.WriteString("PHASE\tDURATION\n")
+	for _, entry := range t.entries {
+		output.WriteString(fmt.Sprintf("%s\t%s\n", entry.phase, entry.duration.Round(time.Millisecond)))
+	}
+	return output.String()
+}
+
+// write saves the recorded timings to a file
+func (t *phaseTimings) write(path string) error {
+	return os.WriteFile(path, []byte(t.format()), 0644)
+}
@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// schedulingEventReasons are the event reasons explaining why pods are or are not running
+var schedulingEventReasons = []string{"FailedScheduling", "Preempted", "Scheduled"}
+
+// maxSchedulingEvents bounds the number of events listed per reason in unschedulable.txt
+const maxSchedulingEvents = 200
+
+// getUnschedulableSummary lists the cluster's Pending pods with their scheduling
+// conditions and the scheduling events of the events window, answering "why isn't my
+// job running"
+func (c *Collector) getUnschedulableSummary() (string, error) {
+	var output strings.Builder
+
+	cutoff, limited := c.opts.schedulerEventsCutoff(c.startTime)
+	if limited {
+		output.WriteString(fmt.Sprintf("# Scheduling events last seen since %s; pending pods are the current ones\n\n", cutoff.UTC().Format(time.RFC3339)))
+	} else {
+		output.WriteString("# All retained scheduling events; pending pods are the current ones\n\n")
+	}
+
+	pods, err := c.listPods("", metav1.ListOptions{FieldSelector: "status.phase=Pending"})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pending pods: %w", err)
+	}
+
+	output.WriteString(fmt.Sprintf("=== Pending pods (%d) ===\n", len(pods)))
+	for _, pod := range pods {
+		output.WriteString(fmt.Sprintf("%s/%s (scheduler: %s, age: %s)\n",
+			pod.Namespace, pod.Name, pod.Spec.SchedulerName, time.Since(pod.CreationTimestamp.Time).Round(time.Second)))
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodScheduled {
+				continue
+			}
+			output.WriteString(fmt.Sprintf("  PodScheduled=%s reason=%s\n", condition.Status, condition.Reason))
+			if condition.Message != "" {
+				output.WriteString(fmt.Sprintf("  message: %s\n", condition.Message))
+			}
+		}
+	}
+
+	for _, reason := range schedulingEventReasons {
+		events, err := c.clientset.CoreV1().Events("").List(context.TODO(), metav1.ListOptions{FieldSelector: "reason=" + reason})
+		if err != nil {
+			output.WriteString(fmt.Sprintf("\n=== %s events ===\nError: %v\n", reason, err))
+			continue
+		}
+
+		items := eventsSince(events.Items, cutoff, limited)
+		sort.Slice(items, func(i, j int) bool {
+			return eventTime(items[i]).After(eventTime(items[j]))
+		})
+		output.WriteString(fmt.Sprintf("\n=== %s events (%d, newest first) ===\n", reason, len(items)))
+		if len(items) > maxSchedulingEvents {
+			output.WriteString(fmt.Sprintf("(showing the newest %d)\n", maxSchedulingEvents))
+			items = items[:maxSchedulingEvents]
+		}
+		for _, event := range items {
+			output.WriteString(fmt.Sprintf("%s\t%s/%s %s\tx%d\t%s\n",
+				eventTime(event).Format(time.RFC3339),
+				event.InvolvedObject.Namespace,
+				event.InvolvedObject.Name,
+				event.InvolvedObject.Kind,
+				event.Count,
+				strings.TrimSpace(event.Message),
+			))
+		}
+	}
+
+	return output.String(), nil
+}
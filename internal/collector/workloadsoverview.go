@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// gpuFractionAnnotation is the pod annotation holding a fractional GPU request
+const gpuFractionAnnotation = "gpu-fraction"
+
+// workloadDemand is what the active pods of a workload request
+type workloadDemand struct {
+	pods int
+	gpu  float64
+	cpu  resource.Quantity
+}
+
+// getWorkloadsOverview lists every RunAI workload of every type across namespaces with its
+// phase and the GPU and CPU requested by its active pods, the demand side of the
+// scheduler's fairness and preemption decisions
+func (c *Collector) getWorkloadsOverview() (string, error) {
+	demand, err := c.getWorkloadDemand()
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	output.WriteString("# RunAI workloads in all namespaces; PODS, GPU and CPU are requested by the active (not completed) pods\n")
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tTYPE\tNAME\tPHASE\tPODS\tGPU\tCPU\tAGE")
+
+	var notes []string
+	// Totals by phase; pods counts the workloads there
+	totals := map[string]*workloadDemand{}
+	for _, workloadType := range workloadTypes {
+		workloads, err := c.listWorkloadsAllNamespaces(workloadType.resource)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("# %s: %v", workloadType.resource, err))
+			continue
+		}
+
+		for _, workload := range workloads {
+			phase, _, _ := unstructured.NestedString(workload.Object, "status", "phase")
+			pods := demand[workload.GetNamespace()+"/"+workload.GetName()]
+			if pods == nil {
+				pods = &workloadDemand{}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n", workload.GetNamespace(), workloadType.alias, workload.GetName(), valueOrNone(phase),
+				pods.pods, strconv.FormatFloat(pods.gpu, 'f', -1, 64), pods.cpu.String(), time.Since(workload.GetCreationTimestamp().Time).Round(time.Second))
+
+			if totals[phase] == nil {
+				totals[phase] = &workloadDemand{}
+			}
+			totals[phase].pods++
+			totals[phase].gpu += pods.gpu
+			totals[phase].cpu.Add(pods.cpu)
+		}
+	}
+	w.Flush()
+
+	output.WriteString("\n=== Totals by phase ===\n")
+	w = tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PHASE\tWORKLOADS\tGPU\tCPU")
+	var phases []string
+	for phase := range totals {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		total := totals[phase]
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", valueOrNone(phase), total.pods, strconv.FormatFloat(total.gpu, 'f', -1, 64), total.cpu.String())
+	}
+	w.Flush()
+
+	if len(notes) > 0 {
+		output.WriteString("\n" + strings.Join(notes, "\n") + "\n")
+	}
+	return output.String(), nil
+}
+
+// listWorkloadsAllNamespaces lists the workloads of a type in every namespace, sorted by
+// namespace and name
+func (c *Collector) listWorkloadsAllNamespaces(resourceType string) ([]unstructured.Unstructured, error) {
+	gvrList, err := c.gvrsFor(resourceType)
+	var unavailable *resourceUnavailableError
+	if errors.As(err, &unavailable) {
+		return nil, fmt.Errorf("not available (%v)", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, gvr := range gvrList {
+		list, err := c.resourceClient(gvr, "").List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		items := list.Items
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].GetNamespace() != items[j].GetNamespace() {
+				return items[i].GetNamespace() < items[j].GetNamespace()
+			}
+			return items[i].GetName() < items[j].GetName()
+		})
+		return items, nil
+	}
+	return nil, lastErr
+}
+
+// getWorkloadDemand sums the GPU and CPU requests of the active pods of each workload,
+// keyed by namespace/workloadName
+func (c *Collector) getWorkloadDemand() (map[string]*workloadDemand, error) {
+	pods, err := c.listPods("", metav1.ListOptions{LabelSelector: "workloadName"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workload pods: %w", err)
+	}
+
+	demand := map[string]*workloadDemand{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		key := pod.Namespace + "/" + pod.Labels["workloadName"]
+		if demand[key] == nil {
+			demand[key] = &workloadDemand{}
+		}
+		demand[key].pods++
+
+		if fraction, err := strconv.ParseFloat(pod.Annotations[gpuFractionAnnotation], 64); err == nil {
+			demand[key].gpu += fraction
+		}
+		for _, container := range pod.Spec.Containers {
+			gpu, found := container.Resources.Requests[gpuResource]
+			if !found {
+				gpu = container.Resources.Limits[gpuResource]
+			}
+			demand[key].gpu += float64(gpu.Value())
+			if cpu, found := container.Resources.Requests[corev1.ResourceCPU]; found {
+				demand[key].cpu.Add(cpu)
+			}
+		}
+	}
+	return demand, nil
+}
@@ -0,0 +1,148 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"nmcrun/internal/console"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// workloadStatusEvents is the number of most recent events printed by WorkloadStatus
+const workloadStatusEvents = 10
+
+// WorkloadStatus prints the phase and conditions of a RunAI workload, the readiness of its
+// pods and their most recent events, without writing any files
+func (c *Collector) WorkloadStatus(project, workloadType, name string) error {
+	canonicalType, namespace, err := c.resolveWorkload(project, workloadType)
+	if err != nil {
+		return err
+	}
+
+	workload, err := c.getResource(namespace, canonicalType, name)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s in namespace %s: %w", canonicalType, name, namespace, err)
+	}
+	pods, err := c.getPodsWithLabels(namespace, fmt.Sprintf("workloadName=%s", name))
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	console.Printf("📋 %s %s in namespace %s\n", canonicalType, name, namespace)
+	phase, _, _ := unstructured.NestedString(workload.Object, "status", "phase")
+	console.Printf("Phase: %s\n", valueOrNone(phase))
+	console.Printf("%s", formatWorkloadConditions(workload))
+	console.Printf("\n%s", formatWorkloadPods(pods.Items))
+
+	// The events of the workload and of its pods share the namespace
+	involved := map[string]bool{name: true}
+	for _, pod := range pods.Items {
+		involved[pod.Name] = true
+	}
+	events, err := c.clientset.CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		console.Printf("\n⚠️  Warning: Failed to list events: %v\n", err)
+		return nil
+	}
+	var related []corev1.Event
+	for _, event := range c.filterEvents(events.Items) {
+		if involved[event.InvolvedObject.Name] {
+			related = append(related, event)
+		}
+	}
+	sort.Slice(related, func(i, j int) bool {
+		return eventTime(related[i]).Before(eventTime(related[j]))
+	})
+	if len(related) > workloadStatusEvents {
+		related = related[len(related)-workloadStatusEvents:]
+	}
+
+	console.Printf("\nRecent events (%d):\n", len(related))
+	if len(related) > 0 {
+		console.Printf("%s", tabulate(formatEvents(related)))
+	}
+	return nil
+}
+
+// formatWorkloadConditions renders the status conditions of a workload as a table
+func formatWorkloadConditions(workload *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	if len(conditions) == 0 {
+		return "Conditions: <none>\n"
+	}
+
+	var output strings.Builder
+	output.WriteString("Conditions:\n")
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tLAST TRANSITION\tMESSAGE")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		field := func(name string) string {
+			value, _, _ := unstructured.NestedString(condition, name)
+			return valueOrNone(value)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", field("type"), field("status"), field("reason"), field("lastTransitionTime"), field("message"))
+	}
+	w.Flush()
+	return output.String()
+}
+
+// formatWorkloadPods renders the readiness of the workload pods as a table, with the reason
+// of a waiting or terminated container in place of the pod phase
+func formatWorkloadPods(pods []corev1.Pod) string {
+	if len(pods) == 0 {
+		return "Pods: <none>\n"
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Pods (%d):\n", len(pods)))
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tREADY\tSTATUS\tRESTARTS\tAGE\tNODE")
+	for _, pod := range pods {
+		ready := 0
+		restarts := int32(0)
+		status := string(pod.Status.Phase)
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Ready {
+				ready++
+			}
+			restarts += containerStatus.RestartCount
+			if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Reason != "" {
+				status = waiting.Reason
+			} else if terminated := containerStatus.State.Terminated; terminated != nil && terminated.Reason != "" && pod.Status.Phase == corev1.PodRunning {
+				status = terminated.Reason
+			}
+		}
+		if pod.DeletionTimestamp != nil {
+			status = "Terminating"
+		}
+		age := time.Since(pod.CreationTimestamp.Time).Truncate(time.Second)
+		fmt.Fprintf(w, "  %s\t%d/%d\t%s\t%d\t%s\t%s\n", pod.Name, ready, len(pod.Spec.Containers), status, restarts, age, valueOrNone(pod.Spec.NodeName))
+	}
+	w.Flush()
+	return output.String()
+}
+
+// tabulate aligns tab-separated lines into indented columns
+func tabulate(text string) string {
+	var output strings.Builder
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" {
+			fmt.Fprint(w, "  "+line)
+		}
+	}
+	w.Flush()
+	return output.String()
+}
@@ -0,0 +1,207 @@
+// Package console writes progress output, optionally without emoji for terminals,
+// CI log viewers and ticket systems that cannot render them. On a terminal, status
+// lines are colored unless disabled with SetNoColor or the NO_COLOR variable.
+package console
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	mu      sync.Mutex
+	plain   bool
+	noColor bool
+
+	// The terminal check is cached for the file os.Stdout pointed to when it was made,
+	// since callers redirect os.Stdout to stderr when stdout carries an archive
+	checkedFile *os.File
+	checkedTTY  bool
+)
+
+// statusPrefixes replace the emoji that carry a status in plain output
+var statusPrefixes = map[rune]string{
+	'✅': "[OK]",
+	'✓': "[OK]",
+	'🟢': "[OK]",
+	'🎉': "[OK]",
+	'⚠': "[WARN]",
+	'🟡': "[WARN]",
+	'❌': "[ERROR]",
+	'✗': "[ERROR]",
+	'🔴': "[ERROR]",
+}
+
+// statusColors are the ANSI colors of status lines, keyed by their plain prefix
+var statusColors = map[string]string{
+	"[OK]":    "\x1b[32m",
+	"[WARN]":  "\x1b[33m",
+	"[ERROR]": "\x1b[31m",
+}
+
+const colorReset = "\x1b[0m"
+
+// SetPlain forces plain output; without it, plain output is used when stdout is not a terminal
+func SetPlain(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	plain = enabled
+}
+
+// SetNoColor disables colored status lines
+func SetNoColor(disabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	noColor = disabled
+}
+
+// Printf formats and prints progress output to stdout
+func Printf(format string, args ...interface{}) {
+	fmt.Fprint(os.Stdout, Format(fmt.Sprintf(format, args...)))
+}
+
+// Println prints progress output to stdout followed by a newline
+func Println(args ...interface{}) {
+	fmt.Fprint(os.Stdout, Format(fmt.Sprintln(args...)))
+}
+
+// Writer wraps w so every write is formatted like Printf output
+func Writer(w io.Writer) io.Writer {
+	return formattingWriter{w}
+}
+
+type formattingWriter struct {
+	w io.Writer
+}
+
+func (f formattingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(f.w, Format(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Format returns s unchanged, or in plain mode with emoji removed and status emoji at the
+// start of a line replaced by [OK], [WARN] or [ERROR]. When color is enabled, status
+// lines are colored green, yellow or red.
+func Format(s string) string {
+	plainOutput, colorOutput := outputModes()
+	if !plainOutput && !colorOutput {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if plainOutput {
+			line = plainLine(line)
+		}
+		if colorOutput {
+			line = colorLine(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// colorLine colors a status line by its leading status emoji or plain prefix
+func colorLine(line string) string {
+	body := strings.TrimLeftFunc(line, unicode.IsSpace)
+	indent := line[:len(line)-len(body)]
+
+	color, ok := statusColors[lineStatus(body)]
+	if !ok {
+		return line
+	}
+	return indent + color + body + colorReset
+}
+
+// lineStatus returns the plain status prefix a line starts with, or ""
+func lineStatus(body string) string {
+	if status := emojiStatus(body); status != "" {
+		return status
+	}
+	for status := range statusColors {
+		if strings.HasPrefix(body, status) {
+			return status
+		}
+	}
+	return ""
+}
+
+// plainLine rewrites a single line for plain output, keeping its indentation
+func plainLine(line string) string {
+	body := strings.TrimLeftFunc(line, unicode.IsSpace)
+	indent := line[:len(line)-len(body)]
+
+	prefix := emojiStatus(body)
+
+	var stripped strings.Builder
+	for _, r := range body {
+		if !isEmoji(r) {
+			stripped.WriteRune(r)
+		}
+	}
+	text := stripped.String()
+	if len(text) != len(body) {
+		// Emoji are followed by padding spaces that are no longer needed
+		text = strings.TrimLeft(text, " ")
+	}
+
+	switch {
+	case prefix == "":
+		return indent + text
+	case text == "":
+		return indent + prefix
+	}
+	return indent + prefix + " " + text
+}
+
+// isEmoji reports whether r is an emoji or a character only used to compose emoji
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, transport, symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // technical symbols such as ⏱ and ⏭
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // arrows and stars such as ⬆ and ⭐
+		return true
+	case r == 0xFE0F || r == 0x200D || r == 0x2139: // variation selector, joiner, ℹ
+		return true
+	}
+	return false
+}
+
+// emojiStatus returns the plain status prefix for the status emoji a line starts with, or ""
+func emojiStatus(body string) string {
+	for _, r := range body {
+		return statusPrefixes[r]
+	}
+	return ""
+}
+
+// outputModes reports whether emoji should be removed from the output and whether
+// status lines should be colored. Color is only used on a terminal.
+func outputModes() (bool, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if checkedFile != os.Stdout {
+		checkedFile = os.Stdout
+		checkedTTY = IsTerminal(os.Stdout)
+	}
+	return plain || !checkedTTY, checkedTTY && !noColor && os.Getenv("NO_COLOR") == ""
+}
+
+// IsTerminal reports whether f is a character device such as a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -2,23 +2,61 @@ package updater
 
 import (
 	"archive/tar"
+	"bufio"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	"nmcrun/internal/console"
+
 	"nmcrun/internal/version"
 )
 
+// DefaultDownloadTimeout is the default upper bound for downloading a release asset
+const DefaultDownloadTimeout = 10 * time.Minute
+
+// OfflineEnvVar disables all update checks when set to a true value (e.g. NMCRUN_OFFLINE=1)
+const OfflineEnvVar = "NMCRUN_OFFLINE"
+
+// binaryName is the name of the executable inside release archives
+const binaryName = "nmcrun"
+
+// smokeTestTimeout bounds the "version" run of a downloaded binary
+const smokeTestTimeout = 10 * time.Second
+
+// versionOutputPattern matches the first line printed by "nmcrun version"
+var versionOutputPattern = regexp.MustCompile(`(?m)^nmcrun version (\S+)$`)
+
+// ErrOffline is returned by update checks when offline mode is enabled
+var ErrOffline = errors.New("offline mode enabled, update checks are disabled")
+
 type Updater struct {
-	repoOwner string
-	repoName  string
-	client    *http.Client
+	repoOwner       string
+	repoName        string
+	client          *http.Client
+	downloadClient  *http.Client
+	downloadTimeout time.Duration
+	offline         bool
+	// assumeYes installs a new version without asking for confirmation
+	assumeYes bool
+	// signatureKey is the minisign public key file release assets must be signed with
+	signatureKey string
 }
 
 type GitHubRelease struct {
@@ -41,23 +79,75 @@ func New() *Updater {
 		repoOwner: "itay-nvn-nv", // Your GitHub username
 		repoName:  "nmcrun",      // Your repo name
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
 		},
+		// No total timeout here: large binaries on slow links are bounded by
+		// the per-download context instead (see downloadTimeout)
+		downloadClient: &http.Client{
+			Transport: downloadTransport(),
+		},
+		downloadTimeout: DefaultDownloadTimeout,
+		offline:         OfflineFromEnv(),
 	}
 }
 
+// downloadTransport is the default transport, which honors the proxy environment, with
+// longer TLS handshake and response header timeouts for slow links
+func downloadTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSHandshakeTimeout = 30 * time.Second
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return transport
+}
+
+// OfflineFromEnv reports whether offline mode is requested via NMCRUN_OFFLINE
+func OfflineFromEnv() bool {
+	offline, err := strconv.ParseBool(os.Getenv(OfflineEnvVar))
+	return err == nil && offline
+}
+
+// SetOffline enables or disables offline mode. In offline mode no network calls are made.
+func (u *Updater) SetOffline(offline bool) {
+	u.offline = offline
+}
+
+// SetAssumeYes skips the confirmation prompt before a new version replaces the running binary.
+// Without it, upgrading requires an interactive terminal to confirm on.
+func (u *Updater) SetAssumeYes(assumeYes bool) {
+	u.assumeYes = assumeYes
+}
+
 // SetRepository allows customizing the repository
 func (u *Updater) SetRepository(owner, name string) {
 	u.repoOwner = owner
 	u.repoName = name
 }
 
+// SetSignatureKey requires release assets to carry a minisign signature (<asset>.minisig)
+// made with the public key in keyFile; releases without a signature are installed with a warning
+func (u *Updater) SetSignatureKey(keyFile string) {
+	u.signatureKey = keyFile
+}
+
+// SetDownloadTimeout sets the maximum time allowed for downloading a release asset.
+// A zero or negative value disables the timeout.
+func (u *Updater) SetDownloadTimeout(timeout time.Duration) {
+	u.downloadTimeout = timeout
+}
+
 // CheckAndUpgrade checks for updates and upgrades if available
 func (u *Updater) CheckAndUpgrade() error {
-	fmt.Println("🔍 Checking for updates...")
+	if u.offline {
+		console.Printf("📴 Offline mode enabled (--offline or %s): skipping update check\n", OfflineEnvVar)
+		console.Println("💡 Download a newer release manually and replace the binary to upgrade.")
+		return nil
+	}
+	
+	console.Println("🔍 Checking for updates...")
 	
 	currentVersion := version.Get()
-	fmt.Printf("Current version: %s\n", currentVersion)
+	console.Printf("Current version: %s\n", currentVersion)
 	
 	// Get latest release
 	release, err := u.getLatestRelease()
@@ -66,24 +156,24 @@ func (u *Updater) CheckAndUpgrade() error {
 	}
 	
 	if release == nil {
-		fmt.Println("ℹ️  No releases found")
+		console.Println("ℹ️  No releases found")
 		return nil
 	}
 	
 	latestVersion := strings.TrimPrefix(release.TagName, "v")
-	fmt.Printf("Latest version: %s\n", latestVersion)
+	console.Printf("Latest version: %s\n", latestVersion)
 	
 	// Compare versions
 	if currentVersion == latestVersion || currentVersion == "dev" {
-		fmt.Println("✅ You are already running the latest version!")
+		console.Println("✅ You are already running the latest version!")
 		return nil
 	}
 	
-	fmt.Printf("🆕 New version available: %s\n", latestVersion)
-	fmt.Printf("Released: %s\n", release.PublishedAt.Format("2006-01-02 15:04:05"))
+	console.Printf("🆕 New version available: %s\n", latestVersion)
+	console.Printf("Released: %s\n", release.PublishedAt.Format("2006-01-02 15:04:05"))
 	
 	if release.Body != "" {
-		fmt.Printf("\nRelease notes:\n%s\n", release.Body)
+		console.Printf("\nRelease notes:\n%s\n", release.Body)
 	}
 	
 	// Find appropriate asset for current platform
@@ -92,19 +182,51 @@ func (u *Updater) CheckAndUpgrade() error {
 		return fmt.Errorf("no compatible binary found for your platform (%s/%s): %w", runtime.GOOS, runtime.GOARCH, err)
 	}
 	
-	fmt.Printf("\n📥 Downloading %s...\n", assetName)
+	confirmed, err := u.confirmUpgrade(os.Stdin, latestVersion)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		console.Printf("ℹ️  Upgrade cancelled, keeping version %s\n", currentVersion)
+		return nil
+	}
+
+	console.Printf("\n📥 Downloading %s...\n", assetName)
 	
 	// Download and install
-	if err := u.downloadAndInstall(assetURL, assetName); err != nil {
+	if err := u.downloadAndInstall(release, assetURL, assetName); err != nil {
 		return fmt.Errorf("failed to download and install update: %w", err)
 	}
 	
-	fmt.Printf("🎉 Successfully upgraded to version %s!\n", latestVersion)
-	fmt.Println("💡 Please restart nmcrun to use the new version.")
+	console.Printf("🎉 Successfully upgraded to version %s!\n", latestVersion)
+	console.Println("💡 Please restart nmcrun to use the new version.")
 	
 	return nil
 }
 
+// confirmUpgrade asks whether to replace the running binary with the new version, unless
+// --yes was given, and reports whether the user agreed. Without a terminal to ask on,
+// --yes is required rather than upgrading silently.
+func (u *Updater) confirmUpgrade(in *os.File, latestVersion string) (bool, error) {
+	if u.assumeYes {
+		return true, nil
+	}
+	if !console.IsTerminal(in) {
+		return false, fmt.Errorf("version %s is available but cannot be confirmed without a terminal; re-run with --yes to upgrade", latestVersion)
+	}
+
+	console.Printf("\nReplace the running binary with version %s? [y/N]: ", latestVersion)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("no confirmation given: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
 // getLatestRelease fetches the latest release from GitHub
 func (u *Updater) getLatestRelease() (*GitHubRelease, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", u.repoOwner, u.repoName)
@@ -150,6 +272,10 @@ func (u *Updater) findAssetForPlatform(release *GitHubRelease) (string, string,
 	}
 	
 	for _, asset := range release.Assets {
+		// Checksums and signatures carry the platform in their name too
+		if isCompanionAsset(asset.Name) {
+			continue
+		}
 		assetLower := strings.ToLower(asset.Name)
 		
 		for _, possibleName := range possibleNames {
@@ -162,68 +288,182 @@ func (u *Updater) findAssetForPlatform(release *GitHubRelease) (string, string,
 	return "", "", fmt.Errorf("no asset found for platform %s", platform)
 }
 
-// downloadAndInstall downloads the binary and replaces the current executable
-func (u *Updater) downloadAndInstall(url, assetName string) error {
+// downloadAndInstall downloads the release asset, verifies it against the published
+// checksum (and minisign signature when a public key is set), then replaces the
+// current executable with the binary it contains
+func (u *Updater) downloadAndInstall(release *GitHubRelease, url, assetName string) error {
 	// Get current executable path
 	currentExe, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("failed to get current executable path: %w", err)
 	}
-	
-	// Create temporary file
-	tempFile, err := os.CreateTemp("", "nmcrun_update_*")
+
+	// Abort cleanly on Ctrl+C or when the download timeout expires
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if u.downloadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.downloadTimeout)
+		defer cancel()
+	}
+
+	// The checksum and signature are small, so they are fetched while the asset downloads
+	checksums := u.fetchCompanionAsset(ctx, release, append([]string{assetName + ".sha256"}, checksumAssetNames...)...)
+	var signature <-chan companionAsset
+	if u.signatureKey != "" {
+		signature = u.fetchCompanionAsset(ctx, release, assetName+".minisig")
+	}
+
+	// The asset is kept on disk so it can be verified before anything is extracted
+	assetFile, err := os.CreateTemp("", "nmcrun_asset_*")
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
 	}
-	defer tempFile.Close()
-	defer os.Remove(tempFile.Name())
-	
+	defer os.Remove(assetFile.Name())
+	defer assetFile.Close()
+
 	// Download file
-	resp, err := u.client.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
 	}
+	resp, err := u.downloadClient.Do(req)
+	if err != nil {
+		return downloadError(ctx, err)
+	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != 200 {
 		return fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
-	
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(assetFile, hash), resp.Body); err != nil {
+		return fmt.Errorf("failed to write downloaded file: %w", downloadError(ctx, err))
+	}
+
+	// Integrity gate: nothing is installed unless the published checksum and signature match
+	if result, published := <-checksums; published {
+		if err := verifyChecksum(result, assetName, hex.EncodeToString(hash.Sum(nil))); err != nil {
+			return err
+		}
+		console.Printf("✅ Checksum verified (%s)\n", result.name)
+	} else {
+		console.Println("⚠️  No checksum published for this release, skipping checksum verification")
+	}
+	if signature != nil {
+		if result, published := <-signature; published {
+			if result.err != nil {
+				return fmt.Errorf("failed to download %s: %w", result.name, result.err)
+			}
+			if err := verifyMinisign(u.signatureKey, assetFile.Name(), result.content); err != nil {
+				return fmt.Errorf("signature verification of %s failed: %w", assetName, err)
+			}
+			console.Printf("✅ Signature verified (%s)\n", result.name)
+		} else {
+			console.Printf("⚠️  No signature (%s.minisig) published for this release, skipping signature verification\n", assetName)
+		}
+	}
+
+	if _, err := assetFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
 	// Extract binary from archive if needed
-	var binaryReader io.Reader = resp.Body
-	
+	var binaryReader io.Reader = assetFile
+
 	if strings.HasSuffix(assetName, ".tar.gz") || strings.HasSuffix(assetName, ".tgz") {
-		binaryReader, err = u.extractBinaryFromTarGz(resp.Body)
+		binaryReader, err = u.extractBinaryFromTarGz(assetFile)
 		if err != nil {
 			return fmt.Errorf("failed to extract binary from archive: %w", err)
 		}
 	} else if strings.HasSuffix(assetName, ".gz") {
-		gzReader, err := gzip.NewReader(resp.Body)
+		gzReader, err := gzip.NewReader(assetFile)
 		if err != nil {
 			return fmt.Errorf("failed to decompress gzip: %w", err)
 		}
 		defer gzReader.Close()
 		binaryReader = gzReader
 	}
-	
+
+	// Create temporary file; Windows only runs it for the smoke test with an .exe extension
+	pattern := "nmcrun_update_*"
+	if runtime.GOOS == "windows" {
+		pattern += ".exe"
+	}
+	tempFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
 	// Copy to temp file
 	if _, err := io.Copy(tempFile, binaryReader); err != nil {
 		return fmt.Errorf("failed to write downloaded file: %w", err)
 	}
-	
+
 	// Make executable
 	if err := os.Chmod(tempFile.Name(), 0755); err != nil {
 		return fmt.Errorf("failed to make file executable: %w", err)
 	}
-	
+
+	// Smoke test: the current binary is only replaced by one that actually runs here
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write downloaded file: %w", err)
+	}
+	if err := smokeTestBinary(tempFile.Name()); err != nil {
+		return fmt.Errorf("downloaded binary failed to run, keeping the current version: %w", err)
+	}
+
 	// Replace current executable
 	if err := u.replaceExecutable(currentExe, tempFile.Name()); err != nil {
 		return fmt.Errorf("failed to replace executable: %w", err)
 	}
-	
+
+	return nil
+}
+
+// smokeTestBinary runs "<binary> version" and checks that it prints a version, catching
+// binaries built for another libc or architecture before they replace the current one
+func smokeTestBinary(binary string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), smokeTestTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, binary, "version")
+	// The new binary must not check for updates itself
+	cmd.Env = append(os.Environ(), OfflineEnvVar+"=1")
+	output, err := cmd.CombinedOutput()
+	printed := strings.TrimSpace(string(output))
+	if printed == "" {
+		printed = "no output"
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("'version' did not finish within %s", smokeTestTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("'version' failed: %w (%s)", err, printed)
+	}
+
+	match := versionOutputPattern.FindSubmatch(output)
+	if match == nil {
+		return fmt.Errorf("'version' printed no version (%s)", printed)
+	}
+	console.Printf("✅ Downloaded binary runs (version %s)\n", match[1])
 	return nil
 }
 
+// downloadError explains why a download failed when its context was cancelled
+func downloadError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("download timed out (increase with --download-timeout): %w", err)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Errorf("download interrupted: %w", err)
+	}
+	return err
+}
+
 // extractBinaryFromTarGz extracts the binary from a tar.gz archive
 func (u *Updater) extractBinaryFromTarGz(reader io.Reader) (io.Reader, error) {
 	gzReader, err := gzip.NewReader(reader)
@@ -243,8 +483,13 @@ func (u *Updater) extractBinaryFromTarGz(reader io.Reader) (io.Reader, error) {
 			return nil, err
 		}
 		
-		// Look for the binary file (usually named nmcrun or similar)
-		if strings.Contains(header.Name, "nmcrun") && !strings.Contains(header.Name, ".") {
+		// Never trust entry names from a downloaded asset
+		if err := validateArchiveEntryName(header.Name); err != nil {
+			return nil, err
+		}
+		
+		// Only the nmcrun binary itself is extracted
+		if path.Base(header.Name) == binaryName && header.Typeflag == tar.TypeReg {
 			// Create a buffer to hold the binary content
 			var buf strings.Builder
 			if _, err := io.Copy(&buf, tarReader); err != nil {
@@ -257,6 +502,22 @@ func (u *Updater) extractBinaryFromTarGz(reader io.Reader) (io.Reader, error) {
 	return nil, fmt.Errorf("binary not found in archive")
 }
 
+// validateArchiveEntryName rejects absolute entry names and names that escape the archive root.
+// Windows drive letters are rejected on every platform, since filepath only knows them on Windows.
+func validateArchiveEntryName(name string) error {
+	cleaned := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(cleaned) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" || windowsDriveLetter.MatchString(cleaned) {
+		return fmt.Errorf("refusing archive entry with absolute path: %s", name)
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("refusing archive entry with path traversal: %s", name)
+	}
+	return nil
+}
+
+// windowsDriveLetter matches entry names starting with a Windows drive such as C:
+var windowsDriveLetter = regexp.MustCompile(`^[A-Za-z]:`)
+
 // replaceExecutable replaces the current executable with the new one
 func (u *Updater) replaceExecutable(currentPath, newPath string) error {
 	// On Windows, we can't replace a running executable directly
@@ -307,6 +568,10 @@ func (u *Updater) replaceExecutable(currentPath, newPath string) error {
 
 // CheckVersion checks if a new version is available without upgrading
 func (u *Updater) CheckVersion() (*GitHubRelease, bool, error) {
+	if u.offline {
+		return nil, false, ErrOffline
+	}
+	
 	release, err := u.getLatestRelease()
 	if err != nil {
 		return nil, false, err
@@ -0,0 +1,137 @@
+package updater
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestValidateArchiveEntryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr bool
+	}{
+		{"binary", "nmcrun", false},
+		{"binary in a directory", "nmcrun_linux_amd64/nmcrun", false},
+		{"dot directory", "./nmcrun", false},
+		{"traversal", "../../etc/x", true},
+		{"parent", "..", true},
+		{"traversal after cleaning", "a/../../x", true},
+		{"backslash traversal", "..\\..\\etc\\x", true},
+		{"absolute", "/abs", true},
+		{"windows volume", "C:\\Windows\\nmcrun.exe", true},
+		{"windows volume with slashes", "C:/nmcrun", true},
+		{"unc path", "\\\\server\\share\\nmcrun", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArchiveEntryName(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateArchiveEntryName(%q) error = %v, want error %v", tt.entry, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// tarFile is a regular file written by tarGz
+type tarFile struct {
+	name    string
+	content string
+}
+
+// tarGz builds a tar.gz archive of regular files, in order
+func tarGz(t *testing.T, files ...tarFile) io.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzWriter)
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0755, Size: int64(len(file.content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestExtractBinaryFromTarGzRejectsTraversal(t *testing.T) {
+	archive := tarGz(t, tarFile{"../../etc/x", "evil"}, tarFile{"nmcrun", "binary"})
+
+	_, err := (&Updater{}).extractBinaryFromTarGz(archive)
+	if err == nil || !strings.Contains(err.Error(), "path traversal") {
+		t.Fatalf("extractBinaryFromTarGz error = %v, want a path traversal error", err)
+	}
+}
+
+func TestExtractBinaryFromTarGz(t *testing.T) {
+	archive := tarGz(t, tarFile{"README.md", "docs"}, tarFile{"nmcrun_linux_amd64/nmcrun", "binary"})
+
+	reader, err := (&Updater{}).extractBinaryFromTarGz(archive)
+	if err != nil {
+		t.Fatalf("extractBinaryFromTarGz: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "binary" {
+		t.Errorf("extracted %q, want %q", content, "binary")
+	}
+}
+
+func TestUpdaterUsesHTTPSProxy(t *testing.T) {
+	var mu sync.Mutex
+	var tunnels []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodConnect {
+			tunnels = append(tunnels, r.Host)
+		}
+		http.Error(w, "proxy test", http.StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	// net/http reads the proxy environment once per process, before any request is made
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+
+	u := New()
+	if _, err := u.getLatestRelease(); err == nil {
+		t.Fatal("getLatestRelease succeeded through a proxy refusing every tunnel")
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://github.com/itay-nvn-nv/nmcrun/releases/download/v1.0.0/nmcrun_linux_amd64.tar.gz", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := u.downloadClient.Do(req); err == nil {
+		resp.Body.Close()
+		t.Fatal("download succeeded through a proxy refusing every tunnel")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"api.github.com:443", "github.com:443"}
+	if strings.Join(tunnels, ",") != strings.Join(want, ",") {
+		t.Errorf("proxy tunnels = %v, want %v", tunnels, want)
+	}
+}
@@ -0,0 +1,203 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// checksumAssetNames are the release assets searched for the SHA-256 of the binary asset,
+// besides <asset>.sha256
+var checksumAssetNames = []string{"checksums.txt", "SHA256SUMS", "sha256sums.txt"}
+
+// companionAssetSuffixes mark checksum and signature assets, which are never the binary asset
+var companionAssetSuffixes = []string{".minisig", ".sha256", ".txt"}
+
+// isCompanionAsset reports whether a release asset is a checksum or signature file
+func isCompanionAsset(name string) bool {
+	for _, companion := range checksumAssetNames {
+		if strings.EqualFold(name, companion) {
+			return true
+		}
+	}
+	for _, suffix := range companionAssetSuffixes {
+		if strings.HasSuffix(strings.ToLower(name), suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxCompanionAssetSize bounds checksum and signature downloads
+const maxCompanionAssetSize = 1 << 20
+
+// companionAsset is a small file published next to the binary asset, fetched concurrently with it
+type companionAsset struct {
+	name    string
+	content []byte
+	err     error
+}
+
+// findCompanionAsset returns the download URL and name of the first release asset with one of the names
+func findCompanionAsset(release *GitHubRelease, names ...string) (string, string, bool) {
+	for _, name := range names {
+		for _, asset := range release.Assets {
+			if asset.Name == name {
+				return asset.DownloadURL, asset.Name, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// fetchCompanionAsset downloads the first of the named release assets in the background.
+// The channel is closed without a value when the release has none of them.
+func (u *Updater) fetchCompanionAsset(ctx context.Context, release *GitHubRelease, names ...string) <-chan companionAsset {
+	result := make(chan companionAsset, 1)
+	url, name, found := findCompanionAsset(release, names...)
+	if !found {
+		close(result)
+		return result
+	}
+
+	go func() {
+		defer close(result)
+		content, err := u.fetchSmallAsset(ctx, url)
+		result <- companionAsset{name: name, content: content, err: err}
+	}()
+	return result
+}
+
+// fetchSmallAsset downloads a release asset of at most maxCompanionAssetSize bytes
+func (u *Updater) fetchSmallAsset(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxCompanionAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxCompanionAssetSize {
+		return nil, fmt.Errorf("asset is larger than %d bytes", maxCompanionAssetSize)
+	}
+	return content, nil
+}
+
+// expectedChecksum finds the SHA-256 of assetName in a checksum file, which either lists
+// "<hex>  <name>" lines (sha256sum format) or holds a single digest
+func expectedChecksum(content []byte, assetName string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 1 && len(lines) == 1:
+			return strings.ToLower(fields[0]), nil
+		case len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == assetName:
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum listed for %s", assetName)
+}
+
+// verifyChecksum compares the SHA-256 of the downloaded asset with the published one
+func verifyChecksum(checksums companionAsset, assetName, actual string) error {
+	if checksums.err != nil {
+		return fmt.Errorf("failed to download %s: %w", checksums.name, checksums.err)
+	}
+	expected, err := expectedChecksum(checksums.content, assetName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", checksums.name, err)
+	}
+	if expected != actual {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, expected, actual)
+	}
+	return nil
+}
+
+// verifyMinisign verifies a minisign signature of the file at path with the public key
+// stored in keyFile. Only legacy (non-prehashed, "minisign -S -l") signatures are supported.
+func verifyMinisign(keyFile, path string, signature []byte) error {
+	keyContent, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+	key, err := decodeMinisignLine(keyContent, 42)
+	if err != nil {
+		return fmt.Errorf("invalid minisign public key %s: %w", keyFile, err)
+	}
+	if string(key[:2]) != "Ed" {
+		return fmt.Errorf("unsupported minisign public key algorithm %q", key[:2])
+	}
+	keyID, publicKey := key[2:10], ed25519.PublicKey(key[10:])
+
+	// A signature file holds: untrusted comment, signature, trusted comment, global signature
+	lines := strings.Split(strings.TrimSpace(string(signature)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 74 {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	switch string(sig[:2]) {
+	case "Ed":
+	case "ED":
+		return fmt.Errorf("prehashed minisign signatures are not supported, sign the release with minisign -S -l")
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm %q", sig[:2])
+	}
+	if !bytes.Equal(sig[2:10], keyID) {
+		return fmt.Errorf("signature was made with a different key (key ID %X, expected %X)", sig[2:10], keyID)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, data, sig[10:]) {
+		return fmt.Errorf("signature verification failed")
+	}
+
+	trustedComment := strings.TrimPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || !ed25519.Verify(publicKey, append(append([]byte{}, sig[10:]...), trustedComment...), globalSig) {
+		return fmt.Errorf("trusted comment signature verification failed")
+	}
+	return nil
+}
+
+// decodeMinisignLine decodes the first base64 line of a minisign file that is not a
+// comment, which must decode to size bytes
+func decodeMinisignLine(content []byte, size int) ([]byte, error) {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, err
+		}
+		if len(decoded) != size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", size, len(decoded))
+		}
+		return decoded, nil
+	}
+	return nil, fmt.Errorf("no key found")
+}
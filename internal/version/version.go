@@ -2,6 +2,8 @@ package version
 
 import (
 	"runtime"
+	"runtime/debug"
+	"strings"
 )
 
 // Build-time variables set by ldflags during build
@@ -11,6 +13,40 @@ var (
 	GitCommit = "unknown"
 )
 
+// Values of the build-time variables when no ldflags were given
+const (
+	unsetVersion = "dev"
+	unsetValue   = "unknown"
+)
+
+// init falls back to the module build information embedded by the Go toolchain
+// when the ldflags variables are unset, e.g. for binaries built with go install
+func init() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+
+	// Local builds of a modified checkout stay "dev" so they never offer to upgrade themselves
+	if Version == unsetVersion && info.Main.Version != "" && info.Main.Version != "(devel)" &&
+		!strings.HasSuffix(info.Main.Version, "+dirty") {
+		Version = info.Main.Version
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if GitCommit == unsetValue {
+				GitCommit = setting.Value
+			}
+		case "vcs.time":
+			if BuildDate == unsetValue {
+				BuildDate = setting.Value
+			}
+		}
+	}
+}
+
 // Get returns the current version
 func Get() string {
 	return Version
@@ -36,6 +72,26 @@ func GetGoVersion() string {
 	return runtime.Version()
 }
 
+// Info is the build information reported by the version command
+type Info struct {
+	Version   string `json:"version"`
+	BuildDate string `json:"buildDate"`
+	GitCommit string `json:"gitCommit"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// GetInfo returns all build information
+func GetInfo() Info {
+	return Info{
+		Version:   Get(),
+		BuildDate: GetBuildDate(),
+		GitCommit: GetCommit(),
+		GoVersion: GetGoVersion(),
+		Platform:  GetPlatform(),
+	}
+}
+
 // GetPlatform returns the platform info
 func GetPlatform() string {
 	return runtime.GOOS + "/" + runtime.GOARCH
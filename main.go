@@ -1,14 +1,22 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"nmcrun/internal/collector"
+	"nmcrun/internal/console"
 	"nmcrun/internal/updater"
 	"nmcrun/internal/version"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var rootCmd = &cobra.Command{
@@ -16,19 +24,61 @@ var rootCmd = &cobra.Command{
 	Short: "RunAI log collector and environment diagnostic tool",
 	Long: `nmcrun is a tool that collects logs and environment details from RunAI deployments
 and archives them for support analysis.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		plain, _ := cmd.Flags().GetBool("plain")
+		console.SetPlain(plain)
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		console.SetNoColor(noColor)
+
+		proxy, _ := cmd.Flags().GetString("proxy")
+		return applyProxy(proxy)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Default action: show help
 		cmd.Help()
 	},
 }
 
+// applyProxy routes Kubernetes and GitHub traffic through the given proxy URL.
+// Both clients resolve proxies from the environment, so this sets the standard
+// proxy variables; NO_PROXY is still honored.
+func applyProxy(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return fmt.Errorf("invalid --proxy %q (expected a URL such as http://proxy.example.com:3128)", proxy)
+	}
+
+	for _, name := range []string{"HTTPS_PROXY", "HTTP_PROXY", "https_proxy", "http_proxy"} {
+		os.Setenv(name, proxy)
+	}
+	return nil
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("nmcrun version %s\n", version.Get())
-		fmt.Printf("Build date: %s\n", version.GetBuildDate())
-		fmt.Printf("Git commit: %s\n", version.GetCommit())
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := version.GetInfo()
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			data, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("nmcrun version %s\n", info.Version)
+		fmt.Printf("Build date: %s\n", info.BuildDate)
+		fmt.Printf("Git commit: %s\n", info.GitCommit)
+		fmt.Printf("Go version: %s\n", info.GoVersion)
+		fmt.Printf("Platform: %s\n", info.Platform)
+		return nil
 	},
 }
 
@@ -36,17 +86,31 @@ var logsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Collect logs and environment details from RunAI deployment",
 	Long: `Collects logs from RunAI pods, cluster configuration, and environment details.
-Creates timestamped archives for each namespace (runai and runai-backend).`,
+Creates timestamped archives for each namespace (runai and runai-backend).
+` + exitCodesHelp,
 	Run: func(cmd *cobra.Command, args []string) {
-		collector, err := collector.New()
+		output, _ := cmd.Flags().GetString("output")
+
+		// Keep stdout for the archive payload and send all progress output to stderr
+		archiveWriter := os.Stdout
+		if output == "-" {
+			os.Stdout = os.Stderr
+		}
+
+		collector, err := newCollector(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error initializing collector: %v\n", err)
 			os.Exit(1)
 		}
-		if err := collector.Run(); err != nil {
+		collector.SetArchiveWriter(archiveWriter)
+		if err := confirmTarget(cmd, collector); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if err := collector.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
 	},
 }
 
@@ -54,16 +118,17 @@ var testCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Test environment and connectivity for RunAI log collection",
 	Long: `Tests Kubernetes cluster connectivity and displays RunAI cluster information 
-including control plane and cluster URLs. No external tools required.`,
+including control plane and cluster URLs. No external tools required.
+` + exitCodesHelp,
 	Run: func(cmd *cobra.Command, args []string) {
-		collector, err := collector.New()
+		collector, err := newCollector(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error initializing collector: %v\n", err)
 			os.Exit(1)
 		}
 		if err := collector.RunTests(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 	},
 }
@@ -77,18 +142,37 @@ pod logs, and related resources. Creates a timestamped archive for analysis.`,
 		project, _ := cmd.Flags().GetString("project")
 		workloadType, _ := cmd.Flags().GetString("type")
 		name, _ := cmd.Flags().GetString("name")
+		interactive, _ := cmd.Flags().GetBool("interactive")
 
-		if project == "" || workloadType == "" || name == "" {
-			fmt.Fprintf(os.Stderr, "Error: --project, --type, and --name are required\n")
+		if !interactive && (project == "" || workloadType == "" || name == "") {
+			fmt.Fprintf(os.Stderr, "Error: --project, --type, and --name are required (or use --interactive)\n")
 			cmd.Usage()
 			os.Exit(1)
 		}
 
-		collector, err := collector.New()
+		collector, err := newCollector(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error initializing collector: %v\n", err)
 			os.Exit(1)
 		}
+		if err := confirmTarget(cmd, collector); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if interactive {
+			project, workloadType, name, err = collector.SelectWorkload(os.Stdin, console.Writer(os.Stdout))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if statusOnly, _ := cmd.Flags().GetBool("status-only"); statusOnly {
+			if err := collector.WorkloadStatus(project, workloadType, name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 		if err := collector.CollectWorkloadInfo(project, workloadType, name); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -102,11 +186,15 @@ var schedulerCmd = &cobra.Command{
 	Long: `Collects comprehensive RunAI scheduler information including projects, queues,
 nodepools, and departments. Creates a timestamped archive with all resources.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		collector, err := collector.New()
+		collector, err := newCollector(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error initializing collector: %v\n", err)
 			os.Exit(1)
 		}
+		if err := confirmTarget(cmd, collector); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		if err := collector.CollectSchedulerInfo(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -114,11 +202,114 @@ nodepools, and departments. Creates a timestamped archive with all resources.`,
 	},
 }
 
+var incidentCmd = &cobra.Command{
+	Use:   "incident",
+	Short: "Collect logs, events and metrics of an incident time window into one bundle",
+	Long: `Collects pod logs, events and a metrics snapshot of the RunAI namespaces, all bounded
+by the same time window, into a single archive. INCIDENT.md summarizes the window, the
+pods that restarted, failed or emitted warnings in it, and the notable events.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !cmd.Flags().Changed("since") && !cmd.Flags().Changed("since-time") {
+			fmt.Fprintln(os.Stderr, "Error: an incident window is required (--since or --since-time)")
+			os.Exit(1)
+		}
+
+		collector, err := newCollector(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing collector: %v\n", err)
+			os.Exit(1)
+		}
+		if err := confirmTarget(cmd, collector); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := collector.CollectIncident(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check local prerequisites: kubeconfig, authentication, output directory, disk space and updates",
+	Long: `Checks the local setup before a collection, without querying the cluster: that the
+kubeconfig files are readable and valid, which authentication method would be used and why,
+that the output directory is writable and has enough free space, and that the update server
+is reachable. Every problem is printed with a remediation.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, _ := cmd.Flags().GetString("dir")
+		offline, _ := cmd.Flags().GetBool("offline")
+
+		updateCheck := func() (string, error) {
+			u := updater.New()
+			u.SetOffline(offline)
+			release, updateAvailable, err := u.CheckVersion()
+			switch {
+			case errors.Is(err, updater.ErrOffline):
+				return "skipped (offline mode)", nil
+			case err != nil:
+				return "", err
+			case release == nil:
+				return "reachable, no releases published", nil
+			case updateAvailable:
+				return fmt.Sprintf("reachable, version %s is available (run 'nmcrun upgrade')", release.TagName), nil
+			}
+			return fmt.Sprintf("reachable, %s is the latest version", release.TagName), nil
+		}
+
+		if err := collector.Doctor(dir, updateCheck); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <archive>",
+	Short: "Summarize an existing archive without extracting it",
+	Long: `Reads an archive created by nmcrun (.tar.gz, .tar.zst, .zip or .tar) end to end and
+prints its files as a tree with their sizes, the total size, the number of pods and
+containers with logs, and the contents of manifest.json and errors.txt if present.
+Use it to confirm a collection is complete before sending it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := collector.Inspect(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var selftestCmd = &cobra.Command{
+	Use:    "selftest",
+	Short:  "Verify that archives are created and read back correctly on this system",
+	Hidden: true,
+	Long: `Creates sample files in a temporary directory, archives them with the same code
+used by the logs and workloads commands, then re-reads and verifies the archives.
+No cluster connection is needed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := collector.SelfTest(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
 var upgradeCmd = &cobra.Command{
 	Use:   "upgrade",
 	Short: "Check for updates and upgrade to latest version",
 	Run: func(cmd *cobra.Command, args []string) {
+		downloadTimeout, _ := cmd.Flags().GetDuration("download-timeout")
+		offline, _ := cmd.Flags().GetBool("offline")
+
 		updater := updater.New()
+		updater.SetDownloadTimeout(downloadTimeout)
+		signatureKey, _ := cmd.Flags().GetString("verify-signature")
+		updater.SetSignatureKey(signatureKey)
+		updater.SetOffline(offline)
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		updater.SetAssumeYes(assumeYes)
 		if err := updater.CheckAndUpgrade(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error during upgrade: %v\n", err)
 			os.Exit(1)
@@ -126,21 +317,521 @@ var upgradeCmd = &cobra.Command{
 	},
 }
 
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generates a shell completion script for nmcrun.
+
+  bash:       source <(nmcrun completion bash)
+  zsh:        nmcrun completion zsh > "${fpath[1]}/_nmcrun"
+  fish:       nmcrun completion fish > ~/.config/fish/completions/nmcrun.fish
+  powershell: nmcrun completion powershell | Out-String | Invoke-Expression`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		switch args[0] {
+		case "bash":
+			err = rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			err = rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			err = rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			err = rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating completion: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// completionCollector creates a collector for dynamic shell completion. Connection
+// messages are sent to stderr so they do not end up in the completion results.
+func completionCollector(cmd *cobra.Command) (*collector.Collector, error) {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+	return newCollector(cmd)
+}
+
+// registerWorkloadCompletions registers dynamic completion for the workloads flags
+func registerWorkloadCompletions() {
+	workloadsCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return collector.WorkloadTypeAliases(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	workloadsCmd.RegisterFlagCompletionFunc("project", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, err := completionCollector(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		projects, err := c.ListProjects()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return projects, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	workloadsCmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project, _ := cmd.Flags().GetString("project")
+		workloadType, _ := cmd.Flags().GetString("type")
+		if project == "" || workloadType == "" {
+			// Workload names can only be listed once project and type are known
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		c, err := completionCollector(cmd)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		workloads, err := c.ListWorkloads(project, workloadType)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return workloads, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// addLogWindowFlags adds the flags limiting which part of the container logs is collected
+func addLogWindowFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("since", 0, "Only collect log lines newer than this duration (e.g. 2h)")
+	cmd.Flags().String("since-time", "", "Only collect log lines after this RFC3339 time (e.g. 2024-06-01T10:00:00Z)")
+}
+
+// addContainerFlags adds the flags selecting which containers have their logs collected
+func addContainerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("exclude-containers", collector.DefaultExcludeContainers, "Container names whose logs are skipped ('*' wildcard, e.g. service-mesh sidecars)")
+	cmd.Flags().Bool("include-all-containers", false, "Collect logs from every container, ignoring --exclude-containers")
+}
+
+// addGrepFlags adds the flags filtering collected log lines by a regular expression
+func addGrepFlags(cmd *cobra.Command) {
+	cmd.Flags().String("grep", "", "Also write the log lines matching this regular expression to <pod>_<container>.filtered.log")
+	cmd.Flags().Int("grep-context", 0, "Lines of context to keep before and after each --grep match")
+	cmd.Flags().Bool("grep-only", false, "Only write the --grep filtered logs, not the full logs")
+}
+
+// addExtraResourceFlags adds the flag dumping resources not collected by default
+func addExtraResourceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("extra-resource", nil, "Also dump this resource as YAML, as group/version/resource[:name] (e.g. run.ai/v2alpha1/projects, v1/configmaps:my-config); repeatable")
+}
+
+// addEventFlags adds the flag limiting which events are collected
+func addEventFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("events-since", 0, "Only collect events last seen within this duration (defaults to the --since/--since-time window, 0 for all retained events)")
+}
+
+// addProfileFlags adds the collection profile flag and the options it can be overridden with
+func addProfileFlags(cmd *cobra.Command) {
+	cmd.Flags().String("profile", "", fmt.Sprintf("Collection profile: built-in name (%s) or path to a profile YAML file", strings.Join(collector.BuiltinProfileNames(), ", ")))
+	cmd.Flags().StringSlice("strip-annotations", nil, "Annotation keys to drop from dumped YAML ('*' wildcard, e.g. kubectl.kubernetes.io/last-applied-configuration,checksum/*)")
+	cmd.Flags().StringSlice("keep-annotations", nil, "Only keep annotation keys matching these patterns in dumped YAML ('*' wildcard)")
+	cmd.Flags().StringSlice("strip-labels", nil, "Label keys to drop from dumped YAML ('*' wildcard)")
+}
+
+// addTimestampFlags adds the archive name timestamp flags
+func addTimestampFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("utc", false, "Use UTC instead of local time in archive names")
+	cmd.Flags().Bool("legacy-timestamps", false, "Use the old minute-granularity DD-MM-YYYY_HH-MM archive name timestamps")
+}
+
+// addArchiveFlags adds the archive format flags
+func addArchiveFlags(cmd *cobra.Command) {
+	cmd.Flags().String("format", collector.FormatTarGz, fmt.Sprintf("Archive format: %s", strings.Join(collector.ArchiveFormats, ", ")))
+	cmd.Flags().Bool("gzip-archive", true, "Gzip the archive; use --gzip-archive=false for a plain .tar (same as --format=tar, conflicts with any other --format)")
+	cmd.Flags().Int64("max-archive-bytes", 0, "Abort and remove the archive if it grows beyond this many bytes (0 for no limit)")
+	cmd.Flags().String("output-prefix", "", "Prefix prepended to every archive and top-level directory name (e.g. CASE-1234_)")
+}
+
+// addProgressFlags adds the machine-readable progress stream flag
+func addProgressFlags(cmd *cobra.Command) {
+	cmd.Flags().String("events-out", "", "Write each collection step as a newline-delimited JSON event to this file ('-' for stderr)")
+}
+
+// addContextFlags adds the flags guarding against collecting from the wrong cluster
+func addContextFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("confirm-context", false, "Print the target context and cluster URL and ask for confirmation before collecting")
+	cmd.Flags().String("expect-context", "", "Only collect if the target context matches this name (non-interactive alternative to --confirm-context)")
+}
+
+// exitCodesHelp documents the exit codes of the logs and test commands
+const exitCodesHelp = `
+Exit codes:
+  0  healthy, everything collected
+  1  any other failure
+  2  the cluster cannot be reached
+  3  a requested RunAI namespace does not exist
+  4  partial collection, e.g. requests Forbidden by RBAC
+  5  the runaiconfig resource cannot be read
+When several problems apply, the lowest of codes 2-5 is reported.`
+
+// exitCode maps a collector error to the process exit code
+func exitCode(err error) int {
+	return collector.ExitCode(err)
+}
+
+// confirmTarget applies --confirm-context/--expect-context before collecting
+func confirmTarget(cmd *cobra.Command, c *collector.Collector) error {
+	confirm, _ := cmd.Flags().GetBool("confirm-context")
+	expectContext, _ := cmd.Flags().GetString("expect-context")
+	return c.ConfirmTarget(os.Stdin, console.Writer(os.Stdout), expectContext, confirm)
+}
+
+// addAPIFlags adds the Kubernetes API client rate limit flags
+func addAPIFlags(cmd *cobra.Command) {
+	cmd.Flags().Float32("api-qps", collector.DefaultAPIQPS, "Maximum Kubernetes API requests per second (higher is faster on large clusters but adds API server load)")
+	cmd.Flags().Int("api-burst", collector.DefaultAPIBurst, "Maximum burst of Kubernetes API requests above --api-qps")
+	cmd.Flags().Int64("page-size", collector.DefaultPageSize, "Number of pods requested per List call (lower bounds memory and API server load on huge namespaces)")
+	cmd.Flags().Int("max-inflight", collector.DefaultMaxInflight, "Maximum Kubernetes API requests in flight at once across all namespaces and workers (0 for no limit)")
+}
+
+// collectorOptions builds collector options from the --profile flag, then applies
+// any explicitly set command-line flags on top of it
+func collectorOptions(cmd *cobra.Command) (collector.CollectorOptions, error) {
+	opts := collector.DefaultOptions()
+	flags := cmd.Flags()
+
+	if profile, _ := flags.GetString("profile"); profile != "" {
+		loaded, err := collector.LoadProfile(profile)
+		if err != nil {
+			return opts, err
+		}
+		opts = loaded
+	}
+
+	if flags.Changed("namespaces") {
+		opts.Namespaces, _ = flags.GetStringSlice("namespaces")
+	}
+	if flags.Changed("namespace-selector") {
+		opts.NamespaceSelector, _ = flags.GetString("namespace-selector")
+	}
+	if flags.Changed("all-runai-namespaces") {
+		opts.AllRunAINamespaces, _ = flags.GetBool("all-runai-namespaces")
+	}
+	if flags.Changed("exclude-namespaces") {
+		opts.ExcludeNamespaces, _ = flags.GetStringSlice("exclude-namespaces")
+	}
+	if flags.Changed("exclude-containers") {
+		opts.ExcludeContainers, _ = flags.GetStringSlice("exclude-containers")
+	}
+	if flags.Changed("include-all-containers") {
+		opts.IncludeAllContainers, _ = flags.GetBool("include-all-containers")
+	}
+	if flags.Changed("keep-dir") {
+		opts.KeepDir, _ = flags.GetBool("keep-dir")
+	}
+	if flags.Changed("resume") {
+		opts.Resume, _ = flags.GetString("resume")
+	}
+	if flags.Changed("retry-partial") {
+		retryPartial, _ := flags.GetBool("retry-partial")
+		opts.NoRetryPartial = !retryPartial
+	}
+	if flags.Changed("output") {
+		opts.Output, _ = flags.GetString("output")
+	}
+	if flags.Changed("append-to") {
+		opts.AppendTo, _ = flags.GetString("append-to")
+	}
+	if flags.Changed("crashing-only") {
+		opts.CrashingOnly, _ = flags.GetBool("crashing-only")
+	}
+	if flags.Changed("restart-window") {
+		opts.RestartWindow, _ = flags.GetInt("restart-window")
+	}
+	if flags.Changed("no-timestamps") {
+		opts.NoTimestamps, _ = flags.GetBool("no-timestamps")
+	}
+	if flags.Changed("since") && flags.Changed("since-time") {
+		return opts, fmt.Errorf("--since and --since-time are mutually exclusive")
+	}
+	if flags.Changed("since") {
+		since, _ := flags.GetDuration("since")
+		opts.Since = &metav1.Duration{Duration: since}
+		opts.SinceTime = nil
+	}
+	if flags.Changed("since-time") {
+		value, _ := flags.GetString("since-time")
+		sinceTime, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --since-time %q (expected RFC3339, e.g. 2024-06-01T10:00:00Z): %w", value, err)
+		}
+		opts.SinceTime = &metav1.Time{Time: sinceTime}
+		opts.Since = nil
+	}
+	if flags.Changed("events-since") {
+		eventsSince, _ := flags.GetDuration("events-since")
+		opts.EventsSince = &metav1.Duration{Duration: eventsSince}
+	}
+	if flags.Changed("skip-logs") {
+		opts.SkipLogs, _ = flags.GetBool("skip-logs")
+	}
+	if flags.Changed("logs-only") {
+		opts.LogsOnly, _ = flags.GetBool("logs-only")
+	}
+	if flags.Changed("extra-resource") {
+		opts.ExtraResources, _ = flags.GetStringSlice("extra-resource")
+	}
+	if flags.Changed("workloads-overview") {
+		opts.WorkloadsOverview, _ = flags.GetBool("workloads-overview")
+	}
+	if flags.Changed("insecure") {
+		opts.Insecure, _ = flags.GetBool("insecure")
+	}
+	if flags.Changed("scheduler-config") {
+		opts.SchedulerConfigMap, _ = flags.GetString("scheduler-config")
+	}
+	if flags.Changed("helm-history") {
+		opts.HelmHistory, _ = flags.GetBool("helm-history")
+	}
+	if flags.Changed("include-namespace-yaml") {
+		opts.IncludeNamespaceYAML, _ = flags.GetBool("include-namespace-yaml")
+	}
+	if flags.Changed("compress-logs-individually") {
+		opts.CompressLogsIndividually, _ = flags.GetBool("compress-logs-individually")
+	}
+	if flags.Changed("concurrency") {
+		opts.Concurrency, _ = flags.GetInt("concurrency")
+	}
+	if flags.Changed("utc") {
+		opts.UTC, _ = flags.GetBool("utc")
+	}
+	if flags.Changed("legacy-timestamps") {
+		opts.LegacyTimestamps, _ = flags.GetBool("legacy-timestamps")
+	}
+	if flags.Changed("max-inflight") {
+		opts.MaxInflight, _ = flags.GetInt("max-inflight")
+		if opts.MaxInflight == 0 {
+			opts.MaxInflight = -1
+		}
+	}
+	if flags.Changed("gzip-archive") && flags.Changed("format") {
+		// --gzip-archive picks tar.gz or tar, which must agree with an explicit --format
+		gzipArchive, _ := flags.GetBool("gzip-archive")
+		format, _ := flags.GetString("format")
+		if (gzipArchive && format != collector.FormatTarGz) || (!gzipArchive && format != collector.FormatTar) {
+			return opts, fmt.Errorf("--format %s conflicts with --gzip-archive=%t; use --format alone", format, gzipArchive)
+		}
+	}
+	if flags.Changed("gzip-archive") {
+		gzipArchive, _ := flags.GetBool("gzip-archive")
+		opts.PlainTar = !gzipArchive
+	}
+	if flags.Changed("format") {
+		opts.ArchiveFormat, _ = flags.GetString("format")
+	}
+	if flags.Changed("max-archive-bytes") {
+		opts.MaxArchiveBytes, _ = flags.GetInt64("max-archive-bytes")
+	}
+	if flags.Changed("output-prefix") {
+		opts.OutputPrefix, _ = flags.GetString("output-prefix")
+	}
+	if flags.Changed("api-qps") {
+		opts.APIQPS, _ = flags.GetFloat32("api-qps")
+	}
+	if flags.Changed("api-burst") {
+		opts.APIBurst, _ = flags.GetInt("api-burst")
+	}
+	if flags.Changed("page-size") {
+		opts.PageSize, _ = flags.GetInt64("page-size")
+	}
+	if flags.Changed("include-dns") {
+		opts.IncludeDNS, _ = flags.GetBool("include-dns")
+	}
+	if flags.Changed("dns-namespace") {
+		opts.DNSNamespace, _ = flags.GetString("dns-namespace")
+	}
+	if flags.Changed("dns-selector") {
+		opts.DNSSelector, _ = flags.GetString("dns-selector")
+	}
+	if flags.Changed("probe-registries") {
+		opts.ProbeRegistries, _ = flags.GetBool("probe-registries")
+	}
+	if flags.Changed("prometheus-service") {
+		opts.PrometheusService, _ = flags.GetString("prometheus-service")
+	}
+	if flags.Changed("prometheus-port") {
+		opts.PrometheusPort, _ = flags.GetString("prometheus-port")
+	}
+	if flags.Changed("dedup") {
+		opts.Dedup, _ = flags.GetBool("dedup")
+	}
+	if flags.Changed("pretty-json") {
+		opts.PrettyJSON, _ = flags.GetBool("pretty-json")
+	}
+	if flags.Changed("grep") {
+		opts.Grep, _ = flags.GetString("grep")
+	}
+	if flags.Changed("grep-context") {
+		opts.GrepContext, _ = flags.GetInt("grep-context")
+	}
+	if flags.Changed("grep-only") {
+		opts.GrepOnly, _ = flags.GetBool("grep-only")
+	}
+	if flags.Changed("strip-annotations") {
+		opts.StripAnnotations, _ = flags.GetStringSlice("strip-annotations")
+	}
+	if flags.Changed("keep-annotations") {
+		opts.KeepAnnotations, _ = flags.GetStringSlice("keep-annotations")
+	}
+	if flags.Changed("strip-labels") {
+		opts.StripLabels, _ = flags.GetStringSlice("strip-labels")
+	}
+
+	return opts, nil
+}
+
+// newCollector creates a collector configured from the command's flags
+func newCollector(cmd *cobra.Command) (*collector.Collector, error) {
+	opts, err := collectorOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	c, err := collector.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if flag := cmd.Flags().Lookup("events-out"); flag != nil && flag.Value.String() != "" {
+		progressWriter := io.Writer(os.Stderr)
+		if path := flag.Value.String(); path != "-" {
+			// The file is closed when nmcrun exits; events are written unbuffered
+			file, err := os.Create(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create events file: %w", err)
+			}
+			progressWriter = file
+		}
+		c.SetProgressWriter(progressWriter)
+	}
+	return c, nil
+}
+
 func init() {
+	// Global flags
+	rootCmd.PersistentFlags().Bool("plain", false, "Print progress without emoji, using [OK]/[WARN]/[ERROR] prefixes (automatic when stdout is not a terminal)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Do not color OK/WARN/ERROR status lines (also disabled by NO_COLOR, and when stdout is not a terminal)")
+	rootCmd.PersistentFlags().String("proxy", "", "HTTP(S) proxy URL for Kubernetes and GitHub traffic (overrides HTTPS_PROXY/HTTP_PROXY; NO_PROXY is honored)")
+	rootCmd.PersistentFlags().Bool("offline", updater.OfflineFromEnv(), fmt.Sprintf("Disable all network update checks (also enabled by %s=1)", updater.OfflineEnvVar))
+
+	// Add flags for logs command
+	logsCmd.Flags().Bool("crashing-only", false, "Only collect crashing/restarting pods, including previous logs and container states")
+	logsCmd.Flags().Int("restart-window", 0, fmt.Sprintf("Join this many lines before and after each container restart into <pod>_<container>_restart.log (default %d with --crashing-only)", collector.DefaultRestartWindow))
+	logsCmd.Flags().Bool("no-timestamps", false, "Collect log lines without the RFC3339 timestamp prefix")
+	addLogWindowFlags(logsCmd)
+	addContainerFlags(logsCmd)
+	addGrepFlags(logsCmd)
+	addEventFlags(logsCmd)
+	addExtraResourceFlags(logsCmd)
+	logsCmd.Flags().Bool("skip-logs", false, "Skip pod logs and only collect resources and manifests (small, fast archive)")
+	logsCmd.Flags().Bool("logs-only", false, "Only collect pod logs, skipping resources and manifests")
+	logsCmd.MarkFlagsMutuallyExclusive("skip-logs", "logs-only")
+	logsCmd.Flags().Bool("helm-history", false, "Also list every revision of each Helm release with its status, deploy time and chart version (helm-history-<release>.txt)")
+	logsCmd.Flags().Bool("include-namespace-yaml", false, "Also dump each processed Namespace object as namespace.yaml (on in the full profile)")
+	logsCmd.Flags().Bool("compress-logs-individually", false, "Gzip each log file inside the archive (.log.gz) so single logs can be read without decompressing everything")
+	logsCmd.Flags().Bool("dedup", false, "Collapse consecutive identical log lines into '<line> (repeated N times)'")
+	logsCmd.Flags().Bool("pretty-json", false, "Write JSON-lines container logs as indented .json files with sorted keys")
+	logsCmd.Flags().StringSlice("namespaces", nil, "Namespaces to collect (default runai-backend,runai)")
+	logsCmd.Flags().String("namespace-selector", "", "Collect the namespaces matching this label selector instead of --namespaces (e.g. app.kubernetes.io/part-of=runai)")
+	logsCmd.Flags().Bool("all-runai-namespaces", false, "Also collect every namespace with a RunAI label (e.g. runai-<project> namespaces)")
+	logsCmd.Flags().StringSlice("exclude-namespaces", nil, "Namespaces to skip after discovery ('*' wildcard, e.g. 'runai-test*')")
+	logsCmd.MarkFlagsMutuallyExclusive("namespaces", "namespace-selector")
+	logsCmd.Flags().Bool("keep-dir", false, "Keep the collection directory after archiving (e.g. to --resume or inspect it)")
+	logsCmd.Flags().String("resume", "", "Resume an interrupted collection in this directory, only fetching container logs that are missing or empty")
+	logsCmd.Flags().Bool("retry-partial", true, "Retry container logs and resources that failed (except Forbidden) once more before archiving")
+	logsCmd.Flags().StringP("output", "o", "", "Archive path, or '-' to stream the archive to stdout (requires a single namespace)")
+	logsCmd.Flags().String("append-to", "", "Add this collection under a <timestamp>/ directory of this existing archive (created if missing) instead of leaving separate archives")
+	logsCmd.MarkFlagsMutuallyExclusive("output", "append-to")
+	logsCmd.Flags().Bool("include-dns", false, "Also collect cluster DNS (CoreDNS/kube-dns) pod logs and the coredns ConfigMap into the runai archive")
+	logsCmd.Flags().String("dns-namespace", collector.DefaultDNSNamespace, "Namespace of the cluster DNS pods")
+	logsCmd.Flags().String("dns-selector", collector.DefaultDNSSelector, "Label selector of the cluster DNS pods")
+	logsCmd.Flags().Bool("probe-registries", false, "Check that the registries of the pod images answer, from this machine (reported in image-pull.txt)")
+	logsCmd.Flags().String("prometheus-service", collector.DefaultPrometheusService, "Prometheus service in the runai namespace to fetch scrape targets and alerts from")
+	logsCmd.Flags().String("prometheus-port", collector.DefaultPrometheusPort, "Port (name or number) of the Prometheus service")
+	addProfileFlags(logsCmd)
+	addAPIFlags(logsCmd)
+	addTimestampFlags(logsCmd)
+	addContextFlags(logsCmd)
+	addArchiveFlags(logsCmd)
+	addProgressFlags(logsCmd)
+
+	// Add flags for test command
+	addAPIFlags(testCmd)
+	testCmd.Flags().Bool("insecure", false, "Skip certificate verification when checking that the control plane URL is reachable")
+
 	// Add flags for workloads command
-	workloadsCmd.Flags().StringP("project", "p", "", "RunAI project name (required)")
-	workloadsCmd.Flags().StringP("type", "t", "", "Workload type: tw, iw, infw, dw, dinfw, ew (required)")
-	workloadsCmd.Flags().StringP("name", "n", "", "Workload name (required)")
-	workloadsCmd.MarkFlagRequired("project")
-	workloadsCmd.MarkFlagRequired("type")
-	workloadsCmd.MarkFlagRequired("name")
+	// project/type/name are validated in Run so --interactive can be used instead
+	workloadsCmd.Flags().StringP("project", "p", "", "RunAI project name (required unless --interactive)")
+	workloadsCmd.Flags().StringP("type", "t", "", "Workload type: tw, iw, infw, dw, dinfw, ew (required unless --interactive)")
+	workloadsCmd.Flags().StringP("name", "n", "", "Workload name (required unless --interactive)")
+	workloadsCmd.Flags().BoolP("interactive", "i", false, "Pick the project, workload type and workload from numbered menus")
+	workloadsCmd.Flags().Bool("status-only", false, "Print the workload phase, conditions, pod readiness and recent events without writing any files")
+	registerWorkloadCompletions()
+	workloadsCmd.Flags().Bool("no-timestamps", false, "Collect log lines without the RFC3339 timestamp prefix")
+	workloadsCmd.Flags().Int("concurrency", collector.DefaultConcurrency, "Maximum number of collection steps run in parallel")
+	addLogWindowFlags(workloadsCmd)
+	addContainerFlags(workloadsCmd)
+	addEventFlags(workloadsCmd)
+	addProfileFlags(workloadsCmd)
+	addAPIFlags(workloadsCmd)
+	addTimestampFlags(workloadsCmd)
+	addContextFlags(workloadsCmd)
+	addArchiveFlags(workloadsCmd)
+	addProgressFlags(workloadsCmd)
+
+	// Add flags for doctor command
+	doctorCmd.Flags().String("dir", ".", "Directory collections will be written to")
+
+	// Add flags for scheduler command
+	schedulerCmd.Flags().Int("concurrency", collector.DefaultConcurrency, "Maximum number of resource manifests fetched in parallel")
+	addEventFlags(schedulerCmd)
+	schedulerCmd.Flags().Duration("since", 0, fmt.Sprintf("Only collect scheduling events newer than this duration (default %s when no window is given)", collector.DefaultSchedulerEventsSince))
+	schedulerCmd.Flags().String("since-time", "", "Only collect scheduling events after this RFC3339 time (e.g. 2024-06-01T10:00:00Z)")
+	addExtraResourceFlags(schedulerCmd)
+	schedulerCmd.Flags().Bool("workloads-overview", false, "Also list every RunAI workload across namespaces with its phase and requested GPU/CPU in workloads-overview.txt")
+	schedulerCmd.Flags().String("scheduler-config", "", "Scheduler config ConfigMap to collect, as [namespace/]name (default: ConfigMaps named *scheduler* with a scheduler config in the RunAI namespace, runai-scheduler or kai-scheduler; a name alone is looked up in the RunAI namespace)")
+	addProfileFlags(schedulerCmd)
+	addAPIFlags(schedulerCmd)
+	addTimestampFlags(schedulerCmd)
+	addContextFlags(schedulerCmd)
+	addArchiveFlags(schedulerCmd)
+	addProgressFlags(schedulerCmd)
+
+	// Add flags for incident command
+	addLogWindowFlags(incidentCmd)
+	addContainerFlags(incidentCmd)
+	addGrepFlags(incidentCmd)
+	incidentCmd.Flags().StringSlice("namespaces", nil, "Namespaces to collect (default runai-backend,runai)")
+	incidentCmd.Flags().Bool("all-runai-namespaces", false, "Also collect every namespace with a RunAI label (e.g. runai-<project> namespaces)")
+	incidentCmd.Flags().Bool("keep-dir", false, "Keep the collection directory after archiving")
+	addProfileFlags(incidentCmd)
+	addAPIFlags(incidentCmd)
+	addTimestampFlags(incidentCmd)
+	addContextFlags(incidentCmd)
+	addArchiveFlags(incidentCmd)
+
+	// Add flags for version command
+	versionCmd.Flags().Bool("json", false, "Print build information as JSON")
+
+	// Add flags for upgrade command
+	upgradeCmd.Flags().Duration("download-timeout", updater.DefaultDownloadTimeout, "Maximum time allowed for downloading the new binary (0 for no limit)")
+	upgradeCmd.Flags().String("verify-signature", "", "Minisign public key file; verify the downloaded release against its .minisig signature when one is published")
+	upgradeCmd.Flags().BoolP("yes", "y", false, "Install a new version without asking for confirmation (required when not run from a terminal)")
 
 	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(testCmd)
 	rootCmd.AddCommand(workloadsCmd)
 	rootCmd.AddCommand(schedulerCmd)
+	rootCmd.AddCommand(incidentCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(selftestCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(inspectCmd)
 }
 
 func main() {